@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"sync"
+	"time"
 
 	"github.com/sriram15/progressor-todo-app/internal/connection"
 	"github.com/sriram15/progressor-todo-app/internal/database"
@@ -34,6 +35,14 @@ type AppSession struct {
 	skillService          *service.SkillService
 	taskCompletionService *service.TaskCompletionService
 	focusTimerService     *service.FocusTimerService
+	timeEntryService      *service.TimeEntryService
+	streakService         *service.StreakService
+	heartbeatService      *service.HeartbeatService
+	tagService            *service.TagService
+	dueReminderService    *service.DueReminderService
+	searchService         *service.SearchService
+	blueprintService      *service.BlueprintService
+	workflowService       *service.WorkflowService
 }
 
 // NewProgressorApp creates a new App object and initializes the profile manager.
@@ -52,6 +61,26 @@ func (a *ProgressorApp) Startup(app *application.App) {
 	log.Println("Progressor app startup")
 	a.wailsApp = app
 	a.eventBus = events.NewEventBus()
+
+	// Forward setting changes so open settings views stay in sync with changes made elsewhere.
+	a.eventBus.Subscribe(events.SettingChangedTopic, func(eventData interface{}) {
+		a.wailsApp.Event.Emit(events.SettingChangedTopic, eventData)
+	})
+	a.eventBus.Subscribe(events.StaleTimeEntriesTopic, func(eventData interface{}) {
+		a.wailsApp.Event.Emit(events.StaleTimeEntriesTopic, eventData)
+	})
+	a.eventBus.Subscribe(events.IdleDetectedTopic, func(eventData interface{}) {
+		a.wailsApp.Event.Emit(events.IdleDetectedTopic, eventData)
+	})
+	a.eventBus.Subscribe(events.CardDueSoonTopic, func(eventData interface{}) {
+		a.wailsApp.Event.Emit(events.CardDueSoonTopic, eventData)
+	})
+	a.eventBus.Subscribe(events.CardsBulkChangedTopic, func(eventData interface{}) {
+		a.wailsApp.Event.Emit(events.CardsBulkChangedTopic, eventData)
+	})
+	a.eventBus.Subscribe(events.LevelChangedTopic, func(eventData interface{}) {
+		a.wailsApp.Event.Emit(events.LevelChangedTopic, eventData)
+	})
 }
 
 // Shutdown is called when the app is shutting down.
@@ -65,22 +94,45 @@ func (a *ProgressorApp) Shutdown() {
 		if err != nil {
 			log.Printf("Error during card service cleanup on shutdown: %v", err)
 		}
+		a.currentSession.heartbeatService.Stop()
+		a.currentSession.dueReminderService.Stop()
 	}
 }
 
 // NewAppSession creates a new session with all services initialized for a given database connection.
 func NewAppSession(dbManager *connection.DBManager, eventBus *events.EventBus, wailsApp *application.App) (*AppSession, error) {
 	projectService := service.NewProjectService(dbManager)
-	taskCompletionService := service.NewTaskCompletionService(dbManager)
-	settingsService := service.NewSettingService(dbManager)
+	settingsService := service.NewSettingService(dbManager, eventBus)
+	taskCompletionService := service.NewTaskCompletionService(dbManager, settingsService, eventBus)
 	skillService := service.NewSkillService(dbManager, eventBus, projectService)
-	progressService := service.NewProgressService(dbManager)
-	cardService := service.NewCardService(projectService, taskCompletionService, dbManager, eventBus)
-	focusTimerService := service.NewFocusTimerService(cardService, settingsService, eventBus, wailsApp)
+	progressService := service.NewProgressService(dbManager, settingsService)
+	streakService := service.NewStreakService(dbManager, settingsService)
+	cardService := service.NewCardService(projectService, taskCompletionService, streakService, settingsService, dbManager, eventBus)
+	timeEntryService := service.NewTimeEntryService(projectService, dbManager, eventBus)
+	focusTimerService := service.NewFocusTimerService(cardService, settingsService, dbManager, eventBus, wailsApp)
+	heartbeatService := service.NewHeartbeatService(dbManager, cardService, settingsService, eventBus)
+	tagService := service.NewTagService(projectService, dbManager)
+	dueReminderService := service.NewDueReminderService(dbManager, settingsService, eventBus)
+	searchService := service.NewSearchService(projectService, dbManager)
+	blueprintService := service.NewBlueprintService(dbManager)
+	workflowService := service.NewWorkflowService(projectService, dbManager)
 
 	skillService.RegisterEventHandlers()
+	taskCompletionService.RegisterEventHandlers()
 	focusTimerService.RegisterEventHandlers()
 
+	// Entries left running by a crash are reconciled before the heartbeat overwrites the
+	// last-seen time and before the focus session is restored.
+	if _, err := heartbeatService.Reconcile(); err != nil {
+		log.Printf("Error reconciling stale time entries: %v", err)
+	}
+	if _, err := cardService.PurgeExpiredTrash(); err != nil {
+		log.Printf("Error purging expired trash: %v", err)
+	}
+	heartbeatService.Start()
+	dueReminderService.Start()
+	focusTimerService.Restore()
+
 	log.Println("New AppSession created with DBManager")
 
 	return &AppSession{
@@ -92,6 +144,14 @@ func NewAppSession(dbManager *connection.DBManager, eventBus *events.EventBus, w
 		skillService:          skillService,
 		taskCompletionService: taskCompletionService,
 		focusTimerService:     focusTimerService,
+		timeEntryService:      timeEntryService,
+		streakService:         streakService,
+		heartbeatService:      heartbeatService,
+		tagService:            tagService,
+		dueReminderService:    dueReminderService,
+		searchService:         searchService,
+		blueprintService:      blueprintService,
+		workflowService:       workflowService,
 	}, nil
 }
 
@@ -130,6 +190,12 @@ func (a *ProgressorApp) SwitchProfile(profileID string) error {
 	}
 
 	a.sessionMutex.Lock()
+	if a.currentSession != nil {
+		// The previous profile's focus session is restored when switching back to it.
+		a.currentSession.focusTimerService.Shutdown()
+		a.currentSession.heartbeatService.Stop()
+		a.currentSession.dueReminderService.Stop()
+	}
 	a.currentSession = newSession
 	a.sessionMutex.Unlock()
 
@@ -158,6 +224,13 @@ func (a *ProgressorApp) AddCard(projectID uint, cardTitle string, estimatedMins
 	return err
 }
 
+func (a *ProgressorApp) AddCardWithParams(projectID uint, params service.AddCardParams) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.cardService.AddCardWithParams(projectID, params)
+	})
+	return err
+}
+
 func (a *ProgressorApp) Cleanup() error {
 	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
 		return nil, s.cardService.Cleanup()
@@ -172,6 +245,30 @@ func (a *ProgressorApp) DeleteCard(projectID uint, id uint) error {
 	return err
 }
 
+func (a *ProgressorApp) GetTrash() ([]database.Card, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.cardService.GetTrash()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]database.Card), nil
+}
+
+func (a *ProgressorApp) RestoreCard(projectID uint, id uint) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.cardService.RestoreCard(projectID, id)
+	})
+	return err
+}
+
+func (a *ProgressorApp) PurgeCard(projectID uint, id uint) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.cardService.PurgeCard(projectID, id)
+	})
+	return err
+}
+
 func (a *ProgressorApp) GetActiveTimeEntry(projectID uint, id uint) (*database.TimeEntry, error) {
 	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
 		return s.cardService.GetActiveTimeEntry(projectID, id)
@@ -192,6 +289,16 @@ func (a *ProgressorApp) GetAll(projectID uint, status service.CardStatus) ([]dat
 	return res.([]database.ListCardsRow), nil
 }
 
+func (a *ProgressorApp) GetAllFiltered(projectID uint, status service.CardStatus, filter service.CardFilter) ([]database.ListCardsRow, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.cardService.GetAllFiltered(projectID, status, filter)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]database.ListCardsRow), nil
+}
+
 func (a *ProgressorApp) GetCardById(projectID uint, id uint) (*database.GetCardRow, error) {
 	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
 		return s.cardService.GetCardById(projectID, id)
@@ -209,6 +316,13 @@ func (a *ProgressorApp) StartCard(projectID uint, id uint) error {
 	return err
 }
 
+func (a *ProgressorApp) ForceStartCard(projectID uint, id uint) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.cardService.ForceStartCard(projectID, id)
+	})
+	return err
+}
+
 func (a *ProgressorApp) StopCard(projectID uint, id uint) error {
 	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
 		return nil, s.cardService.StopCard(projectID, id)
@@ -216,6 +330,224 @@ func (a *ProgressorApp) StopCard(projectID uint, id uint) error {
 	return err
 }
 
+func (a *ProgressorApp) PauseCard(projectID uint, id uint) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.cardService.PauseCard(projectID, id)
+	})
+	return err
+}
+
+func (a *ProgressorApp) ResumeCard(projectID uint, id uint) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.cardService.ResumeCard(projectID, id)
+	})
+	return err
+}
+
+func (a *ProgressorApp) GetActivePauses(projectID uint, id uint) ([]database.TimeEntryPause, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.cardService.GetActivePauses(projectID, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]database.TimeEntryPause), nil
+}
+
+func (a *ProgressorApp) ResolveIdleGap(projectID uint, id uint, gapStart time.Time, gapEnd time.Time, resolution string) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.cardService.ResolveIdleGap(projectID, id, gapStart, gapEnd, resolution)
+	})
+	return err
+}
+
+func (a *ProgressorApp) GetChecklist(projectID uint, cardID uint) ([]database.ChecklistItem, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.cardService.GetChecklist(projectID, cardID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]database.ChecklistItem), nil
+}
+
+func (a *ProgressorApp) AddChecklistItem(projectID uint, cardID uint, title string) (*database.ChecklistItem, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.cardService.AddChecklistItem(projectID, cardID, title)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*database.ChecklistItem), nil
+}
+
+func (a *ProgressorApp) ToggleChecklistItem(projectID uint, cardID uint, itemID uint) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.cardService.ToggleChecklistItem(projectID, cardID, itemID)
+	})
+	return err
+}
+
+func (a *ProgressorApp) ReorderChecklistItems(projectID uint, cardID uint, itemIDs []int64) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.cardService.ReorderChecklistItems(projectID, cardID, itemIDs)
+	})
+	return err
+}
+
+func (a *ProgressorApp) DeleteChecklistItem(projectID uint, cardID uint, itemID uint) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.cardService.DeleteChecklistItem(projectID, cardID, itemID)
+	})
+	return err
+}
+
+func (a *ProgressorApp) GetCardTags(projectID uint, cardID uint) ([]database.Tag, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.cardService.GetCardTags(projectID, cardID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]database.Tag), nil
+}
+
+func (a *ProgressorApp) AddCardTag(projectID uint, cardID uint, tagID int64) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.cardService.AddCardTag(projectID, cardID, tagID)
+	})
+	return err
+}
+
+func (a *ProgressorApp) RemoveCardTag(projectID uint, cardID uint, tagID int64) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.cardService.RemoveCardTag(projectID, cardID, tagID)
+	})
+	return err
+}
+
+func (a *ProgressorApp) GetCardDependencies(projectID uint, id uint) (*service.CardDependencies, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.cardService.GetCardDependencies(projectID, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*service.CardDependencies), nil
+}
+
+func (a *ProgressorApp) AddCardDependency(projectID uint, id uint, blockedByID uint) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.cardService.AddCardDependency(projectID, id, blockedByID)
+	})
+	return err
+}
+
+func (a *ProgressorApp) RemoveCardDependency(projectID uint, id uint, blockedByID uint) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.cardService.RemoveCardDependency(projectID, id, blockedByID)
+	})
+	return err
+}
+
+func (a *ProgressorApp) GetTodayCards() ([]database.Card, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.cardService.GetTodayCards()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]database.Card), nil
+}
+
+func (a *ProgressorApp) GetUpcomingCards(days uint) ([]database.Card, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.cardService.GetUpcomingCards(days)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]database.Card), nil
+}
+
+func (a *ProgressorApp) GetOverdueCards() ([]database.Card, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.cardService.GetOverdueCards()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]database.Card), nil
+}
+
+func (a *ProgressorApp) SetCardPriority(projectID uint, id uint, priority int) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.cardService.SetCardPriority(projectID, id, priority)
+	})
+	return err
+}
+
+func (a *ProgressorApp) MoveCard(projectID uint, id uint, beforeID uint, afterID uint) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.cardService.MoveCard(projectID, id, beforeID, afterID)
+	})
+	return err
+}
+
+func (a *ProgressorApp) MoveCardToProject(projectID uint, id uint, targetProjectID uint, skillAttribution string) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.cardService.MoveCardToProject(projectID, id, targetProjectID, skillAttribution)
+	})
+	return err
+}
+
+func (a *ProgressorApp) DuplicateCard(projectID uint, id uint, targetProjectID uint) (*database.GetCardRow, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.cardService.DuplicateCard(projectID, id, targetProjectID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*database.GetCardRow), nil
+}
+
+func (a *ProgressorApp) BulkCardOperation(projectID uint, params service.BulkCardParams) ([]service.BulkCardResult, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.cardService.BulkCardOperation(projectID, params)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]service.BulkCardResult), nil
+}
+
+func (a *ProgressorApp) SetCardRecurrence(projectID uint, id uint, rule string) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.cardService.SetCardRecurrence(projectID, id, rule)
+	})
+	return err
+}
+
+func (a *ProgressorApp) GetRecurrenceHistory(projectID uint, id uint) ([]database.ListRecurrenceHistoryRow, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.cardService.GetRecurrenceHistory(projectID, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]database.ListRecurrenceHistoryRow), nil
+}
+
+func (a *ProgressorApp) GetCardHistory(projectID uint, id uint) ([]database.CardEvent, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.cardService.GetCardHistory(projectID, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]database.CardEvent), nil
+}
+
 func (a *ProgressorApp) UpdateCard(projectID uint, id uint, updateCardParam service.UpdateCardParams) error {
 	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
 		return nil, s.cardService.UpdateCard(projectID, id, updateCardParam)
@@ -230,6 +562,79 @@ func (a *ProgressorApp) UpdateCardStatus(projectID uint, id uint, status service
 	return err
 }
 
+func (a *ProgressorApp) ForceUpdateCardStatus(projectID uint, id uint, status service.CardStatus) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.cardService.ForceUpdateCardStatus(projectID, id, status)
+	})
+	return err
+}
+
+func (a *ProgressorApp) MoveCardToWorkflowStatus(projectID uint, id uint, workflowStatusID int64) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.cardService.MoveCardToWorkflowStatus(projectID, id, workflowStatusID)
+	})
+	return err
+}
+
+func (a *ProgressorApp) ForceMoveCardToWorkflowStatus(projectID uint, id uint, workflowStatusID int64) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.cardService.ForceMoveCardToWorkflowStatus(projectID, id, workflowStatusID)
+	})
+	return err
+}
+
+// TimeEntryService delegates
+func (a *ProgressorApp) AddTimeEntry(projectID uint, cardID uint, startTime time.Time, endTime time.Time) (*database.TimeEntry, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.timeEntryService.AddTimeEntry(projectID, cardID, startTime, endTime)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*database.TimeEntry), nil
+}
+
+func (a *ProgressorApp) DeleteTimeEntry(projectID uint, cardID uint, entryID uint) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.timeEntryService.DeleteTimeEntry(projectID, cardID, entryID)
+	})
+	return err
+}
+
+func (a *ProgressorApp) ListTimeEntries(projectID uint, cardID uint) ([]database.TimeEntry, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.timeEntryService.ListTimeEntries(projectID, cardID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]database.TimeEntry), nil
+}
+
+func (a *ProgressorApp) ListTimeEntriesInRange(from time.Time, to time.Time) ([]database.ListTimeEntriesInRangeRow, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.timeEntryService.ListTimeEntriesInRange(from, to)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]database.ListTimeEntriesInRangeRow), nil
+}
+
+func (a *ProgressorApp) SplitTimeEntry(projectID uint, cardID uint, entryID uint, splitAt time.Time) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.timeEntryService.SplitTimeEntry(projectID, cardID, entryID, splitAt)
+	})
+	return err
+}
+
+func (a *ProgressorApp) UpdateTimeEntry(projectID uint, cardID uint, entryID uint, startTime time.Time, endTime time.Time) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.timeEntryService.UpdateTimeEntry(projectID, cardID, entryID, startTime, endTime)
+	})
+	return err
+}
+
 // ProgressService delegates
 func (a *ProgressorApp) GetDailyTotalMinutes() ([]database.GetDailyTotalMinutesRow, error) {
 	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
@@ -241,6 +646,26 @@ func (a *ProgressorApp) GetDailyTotalMinutes() ([]database.GetDailyTotalMinutesR
 	return res.([]database.GetDailyTotalMinutesRow), nil
 }
 
+func (a *ProgressorApp) GetLevelHistory(userID int64) ([]database.LevelHistory, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.progressService.GetLevelHistory(userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]database.LevelHistory), nil
+}
+
+func (a *ProgressorApp) GetLevelInfo(userID int64) (service.LevelInfo, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.progressService.GetLevelInfo(userID)
+	})
+	if err != nil {
+		return service.LevelInfo{}, err
+	}
+	return res.(service.LevelInfo), nil
+}
+
 func (a *ProgressorApp) GetStats() (service.GetStatsResult, error) {
 	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
 		return s.progressService.GetStats()
@@ -261,6 +686,96 @@ func (a *ProgressorApp) GetTotalExpForUser(userID int64) (float64, error) {
 	return res.(float64), nil
 }
 
+// StreakService delegates
+func (a *ProgressorApp) AddRestDay(day string, note string) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.streakService.AddRestDay(day, note)
+	})
+	return err
+}
+
+func (a *ProgressorApp) GetRestDays() ([]database.StreakRestDay, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.streakService.GetRestDays()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]database.StreakRestDay), nil
+}
+
+func (a *ProgressorApp) GetStreak() (service.StreakInfo, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.streakService.GetStreak()
+	})
+	if err != nil {
+		return service.StreakInfo{}, err
+	}
+	return res.(service.StreakInfo), nil
+}
+
+func (a *ProgressorApp) RemoveRestDay(day string) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.streakService.RemoveRestDay(day)
+	})
+	return err
+}
+
+// FocusTimerService delegates
+func (a *ProgressorApp) GetFocusState() (service.FocusState, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.focusTimerService.GetFocusState(), nil
+	})
+	if err != nil {
+		return service.FocusState{}, err
+	}
+	return res.(service.FocusState), nil
+}
+
+func (a *ProgressorApp) ExtendFocusSession() error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		s.focusTimerService.ExtendSession()
+		return nil, nil
+	})
+	return err
+}
+
+func (a *ProgressorApp) GetFocusSessions(start time.Time, end time.Time) ([]database.FocusSession, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.focusTimerService.GetFocusSessions(start, end)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]database.FocusSession), nil
+}
+
+func (a *ProgressorApp) StopFocusSession() error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		s.focusTimerService.StopAndDeactivate()
+		return nil, nil
+	})
+	return err
+}
+
+// HeartbeatService delegates
+func (a *ProgressorApp) GetLastReconciliation() (*events.StaleTimeEntriesEvent, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.heartbeatService.GetLastReconciliation(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*events.StaleTimeEntriesEvent), nil
+}
+
+func (a *ProgressorApp) ResolveStaleEntry(cardID int64, resolution string) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.heartbeatService.ResolveStaleEntry(cardID, resolution)
+	})
+	return err
+}
+
 // SettingService delegates
 func (a *ProgressorApp) GetAllSettings() ([]service.SettingsItem, error) {
 	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
@@ -289,6 +804,13 @@ func (a *ProgressorApp) SetSetting(key string, value string) error {
 	return err
 }
 
+func (a *ProgressorApp) ResetSetting(key string) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.settingsService.ResetSetting(key)
+	})
+	return err
+}
+
 // SkillService delegates
 func (a *ProgressorApp) CreateSkill(userID int64, name string, description string) (*database.UserSkill, error) {
 	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
@@ -355,6 +877,40 @@ func (a *ProgressorApp) AddProjectSkill(projectID int64, skillID int64) error {
 	return err
 }
 
+func (a *ProgressorApp) ArchiveProject(projectID int64) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.projectService.ArchiveProject(context.Background(), projectID)
+	})
+	return err
+}
+
+func (a *ProgressorApp) CreateProject(name string) (*database.Project, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.projectService.CreateProject(context.Background(), name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*database.Project), nil
+}
+
+func (a *ProgressorApp) DeleteProject(projectID int64, reassignToProjectID int64) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.projectService.DeleteProject(context.Background(), projectID, reassignToProjectID)
+	})
+	return err
+}
+
+func (a *ProgressorApp) GetArchivedProjects() ([]database.Project, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.projectService.GetArchivedProjects(context.Background())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]database.Project), nil
+}
+
 func (a *ProgressorApp) GetProjects() ([]database.Project, error) {
 	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
 		return s.projectService.GetProjects()
@@ -391,3 +947,157 @@ func (a *ProgressorApp) RemoveProjectSkill(projectID int64, skillID int64) error
 	})
 	return err
 }
+
+func (a *ProgressorApp) RenameProject(projectID int64, name string) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.projectService.RenameProject(context.Background(), projectID, name)
+	})
+	return err
+}
+
+func (a *ProgressorApp) UnarchiveProject(projectID int64) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.projectService.UnarchiveProject(context.Background(), projectID)
+	})
+	return err
+}
+
+// TagService delegates
+func (a *ProgressorApp) CreateTag(name string, color string) (*database.Tag, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.tagService.CreateTag(name, color)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*database.Tag), nil
+}
+
+func (a *ProgressorApp) DeleteTag(id int64) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.tagService.DeleteTag(id)
+	})
+	return err
+}
+
+func (a *ProgressorApp) GetTagCounts(projectID uint, status service.CardStatus) ([]database.CountTagsForProjectRow, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.tagService.GetTagCounts(projectID, status)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]database.CountTagsForProjectRow), nil
+}
+
+func (a *ProgressorApp) GetTags() ([]database.Tag, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.tagService.GetTags()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]database.Tag), nil
+}
+
+func (a *ProgressorApp) UpdateTag(id int64, name string, color string) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.tagService.UpdateTag(id, name, color)
+	})
+	return err
+}
+
+// SearchService delegates
+func (a *ProgressorApp) SearchCards(query string, options service.SearchOptions) ([]database.SearchCardsRow, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.searchService.SearchCards(query, options)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]database.SearchCardsRow), nil
+}
+
+// BlueprintService delegates
+func (a *ProgressorApp) GetBlueprints() ([]service.Blueprint, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.blueprintService.GetBlueprints()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]service.Blueprint), nil
+}
+
+func (a *ProgressorApp) SaveBlueprint(blueprint service.Blueprint) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.blueprintService.SaveBlueprint(blueprint)
+	})
+	return err
+}
+
+func (a *ProgressorApp) DeleteBlueprint(name string) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.blueprintService.DeleteBlueprint(name)
+	})
+	return err
+}
+
+func (a *ProgressorApp) InstantiateBlueprint(name string, projectName string) (*database.Project, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.blueprintService.InstantiateBlueprint(name, projectName)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*database.Project), nil
+}
+
+// WorkflowService delegates
+func (a *ProgressorApp) GetWorkflow(projectID uint) (*service.Workflow, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.workflowService.GetWorkflow(projectID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*service.Workflow), nil
+}
+
+func (a *ProgressorApp) AddWorkflowStatus(projectID uint, name string, isDone bool) (*database.WorkflowStatus, error) {
+	res, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return s.workflowService.AddWorkflowStatus(projectID, name, isDone)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*database.WorkflowStatus), nil
+}
+
+func (a *ProgressorApp) UpdateWorkflowStatus(projectID uint, statusID int64, name string, isDone bool) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.workflowService.UpdateWorkflowStatus(projectID, statusID, name, isDone)
+	})
+	return err
+}
+
+func (a *ProgressorApp) ReorderWorkflowStatuses(projectID uint, statusIDs []int64) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.workflowService.ReorderWorkflowStatuses(projectID, statusIDs)
+	})
+	return err
+}
+
+func (a *ProgressorApp) DeleteWorkflowStatus(projectID uint, statusID int64, moveToStatusID int64) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.workflowService.DeleteWorkflowStatus(projectID, statusID, moveToStatusID)
+	})
+	return err
+}
+
+func (a *ProgressorApp) SetWorkflowTransitions(projectID uint, transitions []database.WorkflowTransition) error {
+	_, err := a.withSession(func(s *AppSession) (interface{}, error) {
+		return nil, s.workflowService.SetWorkflowTransitions(projectID, transitions)
+	})
+	return err
+}
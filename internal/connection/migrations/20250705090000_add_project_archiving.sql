@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE Projects ADD COLUMN archivedAt TIMESTAMP DEFAULT NULL;
+
+-- +goose Down
+ALTER TABLE Projects DROP COLUMN archivedAt;
@@ -0,0 +1,9 @@
+-- +goose Up
+CREATE TABLE StreakRestDays (
+    day TEXT PRIMARY KEY,
+    note TEXT,
+    createdAt TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS StreakRestDays;
@@ -0,0 +1,36 @@
+-- +goose Up
+CREATE TABLE LevelHistory (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    userId INTEGER NOT NULL,
+    level INTEGER NOT NULL,
+    totalExp INTEGER NOT NULL,
+    reachedAt TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (userId) REFERENCES UserProfile(id) ON DELETE CASCADE
+);
+
+-- Experience was previously only summed from TaskCompletions; carry it over so that
+-- existing users keep their progress.
+UPDATE UserProfile SET archerExperience = (
+    SELECT IFNULL(SUM(totalExp), 0) FROM TaskCompletions WHERE userId = UserProfile.id
+);
+
+-- Bring the level in line with the carried over experience on the default linear curve,
+-- where level n costs 100 * n EXP. Every level gained is recorded in LevelHistory with the
+-- EXP it was reached at, dated at the migration, and awards a progression point.
+INSERT INTO LevelHistory (userId, level, totalExp)
+WITH RECURSIVE levels(level, reachedExp) AS (
+    SELECT 1, 0
+    UNION ALL
+    SELECT level + 1, reachedExp + 100 * level FROM levels
+    WHERE reachedExp + 100 * level <= (SELECT MAX(archerExperience) FROM UserProfile)
+)
+SELECT u.id, l.level, l.reachedExp FROM UserProfile u
+JOIN levels l ON l.level > u.archerLevel AND l.reachedExp <= u.archerExperience
+ORDER BY u.id, l.level;
+
+UPDATE UserProfile SET
+    archerLevel = IFNULL((SELECT MAX(level) FROM LevelHistory WHERE userId = UserProfile.id), archerLevel),
+    progressionPoints = progressionPoints + (SELECT COUNT(*) FROM LevelHistory WHERE userId = UserProfile.id);
+
+-- +goose Down
+DROP TABLE IF EXISTS LevelHistory;
@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE FocusSessions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    cardId INTEGER NOT NULL,
+    projectId INTEGER NOT NULL,
+    phase TEXT NOT NULL,
+    cycle INTEGER NOT NULL DEFAULT 0,
+    plannedSeconds INTEGER NOT NULL,
+    startedAt TIMESTAMP NOT NULL,
+    endedAt TIMESTAMP,
+    outcome TEXT,
+    interruptions INTEGER NOT NULL DEFAULT 0,
+    FOREIGN KEY (cardId) REFERENCES Cards(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_focus_sessions_started_at ON FocusSessions(startedAt);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_focus_sessions_started_at;
+DROP TABLE IF EXISTS FocusSessions;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- A single row recording when the app was last known to be running for this profile, and
+-- whether it stopped cleanly after that.
+CREATE TABLE AppHeartbeat (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    lastSeenAt TIMESTAMP NOT NULL,
+    stoppedCleanly BOOLEAN NOT NULL DEFAULT false
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS AppHeartbeat;
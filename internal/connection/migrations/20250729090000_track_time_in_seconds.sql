@@ -0,0 +1,24 @@
+-- +goose Up
+-- TimeEntries.duration was stored in whole minutes, truncating every entry. It now holds
+-- seconds. Entries are recomputed from their bounds where the timestamps can be parsed and
+-- scaled otherwise; running entries keep a zero duration.
+UPDATE TimeEntries SET duration = MAX(COALESCE(
+    CAST(ROUND((julianday(endTime) - julianday(startTime)) * 86400) AS INTEGER),
+    duration * 60
+), 0)
+WHERE startTime != endTime;
+
+-- The minute columns are kept up to date for existing readers.
+ALTER TABLE Cards ADD COLUMN trackedSeconds INTEGER NOT NULL DEFAULT 0;
+UPDATE Cards SET trackedSeconds = (
+    SELECT IFNULL(SUM(te.duration), 0) FROM TimeEntries te WHERE te.cardId = Cards.id
+);
+UPDATE Cards SET trackedMins = trackedSeconds / 60;
+
+ALTER TABLE UserSkillProgress ADD COLUMN total_seconds_tracked INTEGER NOT NULL DEFAULT 0;
+UPDATE UserSkillProgress SET total_seconds_tracked = IFNULL(total_minutes_tracked, 0) * 60;
+
+-- +goose Down
+ALTER TABLE UserSkillProgress DROP COLUMN total_seconds_tracked;
+ALTER TABLE Cards DROP COLUMN trackedSeconds;
+UPDATE TimeEntries SET duration = duration / 60;
@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE TimeEntryPauses (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    timeEntryId INTEGER NOT NULL,
+    pausedAt TIMESTAMP NOT NULL,
+    resumedAt TIMESTAMP,
+    FOREIGN KEY (timeEntryId) REFERENCES TimeEntries(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_time_entry_pauses_time_entry_id ON TimeEntryPauses(timeEntryId);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_time_entry_pauses_time_entry_id;
+DROP TABLE IF EXISTS TimeEntryPauses;
@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE ChecklistItems (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    cardId INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    isDone BOOLEAN NOT NULL DEFAULT FALSE,
+    completedAt TIMESTAMP,
+    createdAt TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (cardId) REFERENCES Cards(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_checklist_items_card_id ON ChecklistItems(cardId, position);
+
+-- Completing a checklist item earns partial EXP, recorded as a task completion of the item.
+-- Card completions keep a NULL checklistItemId.
+ALTER TABLE TaskCompletions ADD COLUMN checklistItemId INTEGER;
+
+-- +goose Down
+ALTER TABLE TaskCompletions DROP COLUMN checklistItemId;
+DROP INDEX IF EXISTS idx_checklist_items_card_id;
+DROP TABLE IF EXISTS ChecklistItems;
@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE Tags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+    color TEXT,
+    createdAt TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE CardTags (
+    cardId INTEGER NOT NULL,
+    tagId INTEGER NOT NULL,
+    PRIMARY KEY (cardId, tagId),
+    FOREIGN KEY (cardId) REFERENCES Cards(id) ON DELETE CASCADE,
+    FOREIGN KEY (tagId) REFERENCES Tags(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_card_tags_tag_id ON CardTags(tagId);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_card_tags_tag_id;
+DROP TABLE IF EXISTS CardTags;
+DROP TABLE IF EXISTS Tags;
@@ -0,0 +1,16 @@
+-- +goose Up
+-- dueAt is the deadline of a card and scheduledFor the time the user plans to work on it.
+-- dueReminderSentAt records the due-soon reminder so it is sent once per due date.
+ALTER TABLE Cards ADD COLUMN dueAt TIMESTAMP DEFAULT NULL;
+ALTER TABLE Cards ADD COLUMN scheduledFor TIMESTAMP DEFAULT NULL;
+ALTER TABLE Cards ADD COLUMN dueReminderSentAt TIMESTAMP DEFAULT NULL;
+
+CREATE INDEX idx_cards_due_at ON Cards(dueAt);
+CREATE INDEX idx_cards_scheduled_for ON Cards(scheduledFor);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_cards_scheduled_for;
+DROP INDEX IF EXISTS idx_cards_due_at;
+ALTER TABLE Cards DROP COLUMN dueReminderSentAt;
+ALTER TABLE Cards DROP COLUMN scheduledFor;
+ALTER TABLE Cards DROP COLUMN dueAt;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- A repeating card carries its recurrence rule. Completing it creates the next occurrence as a
+-- new card in the same series; recurrenceSeriesId is the id of the series' first card and
+-- recurrenceIndex counts occurrences from 1.
+ALTER TABLE Cards ADD COLUMN recurrenceRule TEXT DEFAULT NULL;
+ALTER TABLE Cards ADD COLUMN recurrenceSeriesId INTEGER DEFAULT NULL;
+ALTER TABLE Cards ADD COLUMN recurrenceIndex INTEGER NOT NULL DEFAULT 1;
+
+CREATE INDEX idx_cards_recurrence_series ON Cards(recurrenceSeriesId, recurrenceIndex);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_cards_recurrence_series;
+ALTER TABLE Cards DROP COLUMN recurrenceIndex;
+ALTER TABLE Cards DROP COLUMN recurrenceSeriesId;
+ALTER TABLE Cards DROP COLUMN recurrenceRule;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- rank orders the cards of a project manually. It is fractional so a card can be moved between
+-- two others without renumbering the rest; existing cards keep their insertion order.
+ALTER TABLE Cards ADD COLUMN priority INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE Cards ADD COLUMN rank REAL NOT NULL DEFAULT 0;
+UPDATE Cards SET rank = id;
+
+CREATE INDEX idx_cards_project_rank ON Cards(projectId, rank);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_cards_project_rank;
+ALTER TABLE Cards DROP COLUMN rank;
+ALTER TABLE Cards DROP COLUMN priority;
@@ -0,0 +1,67 @@
+-- +goose Up
+-- CardSearch indexes the text of every card for full-text search. Its rowid is the card id and
+-- the checklist column holds the card's checklist item titles. Triggers keep it in sync.
+CREATE VIRTUAL TABLE CardSearch USING fts5(
+    title,
+    description,
+    checklist,
+    tokenize = 'unicode61 remove_diacritics 2'
+);
+
+INSERT INTO CardSearch (rowid, title, description, checklist)
+SELECT c.id, c.title, IFNULL(c.description, ''),
+    IFNULL((SELECT group_concat(ci.title, ' ') FROM ChecklistItems ci WHERE ci.cardId = c.id), '')
+FROM Cards c;
+
+-- +goose StatementBegin
+CREATE TRIGGER cards_search_insert AFTER INSERT ON Cards BEGIN
+    INSERT INTO CardSearch (rowid, title, description, checklist)
+    VALUES (new.id, new.title, IFNULL(new.description, ''), '');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER cards_search_update AFTER UPDATE OF title, description ON Cards BEGIN
+    UPDATE CardSearch SET title = new.title, description = IFNULL(new.description, '')
+    WHERE rowid = new.id;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER cards_search_delete AFTER DELETE ON Cards BEGIN
+    DELETE FROM CardSearch WHERE rowid = old.id;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER checklist_search_insert AFTER INSERT ON ChecklistItems BEGIN
+    UPDATE CardSearch
+    SET checklist = IFNULL((SELECT group_concat(ci.title, ' ') FROM ChecklistItems ci WHERE ci.cardId = new.cardId), '')
+    WHERE rowid = new.cardId;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER checklist_search_update AFTER UPDATE OF title ON ChecklistItems BEGIN
+    UPDATE CardSearch
+    SET checklist = IFNULL((SELECT group_concat(ci.title, ' ') FROM ChecklistItems ci WHERE ci.cardId = new.cardId), '')
+    WHERE rowid = new.cardId;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER checklist_search_delete AFTER DELETE ON ChecklistItems BEGIN
+    UPDATE CardSearch
+    SET checklist = IFNULL((SELECT group_concat(ci.title, ' ') FROM ChecklistItems ci WHERE ci.cardId = old.cardId), '')
+    WHERE rowid = old.cardId;
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER IF EXISTS checklist_search_delete;
+DROP TRIGGER IF EXISTS checklist_search_update;
+DROP TRIGGER IF EXISTS checklist_search_insert;
+DROP TRIGGER IF EXISTS cards_search_delete;
+DROP TRIGGER IF EXISTS cards_search_update;
+DROP TRIGGER IF EXISTS cards_search_insert;
+DROP TABLE IF EXISTS CardSearch;
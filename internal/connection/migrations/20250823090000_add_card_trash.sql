@@ -0,0 +1,12 @@
+-- +goose Up
+-- Deleting a card moves it to the trash by setting deletedAt. Trashed cards and their time and
+-- EXP are left out of every list and statistic until they are restored, and are purged for good
+-- once the trash retention period has passed.
+ALTER TABLE Cards ADD COLUMN deletedAt TIMESTAMP DEFAULT NULL;
+
+CREATE INDEX idx_cards_deleted_at ON Cards(deletedAt);
+
+-- +goose Down
+-- Trashed cards are kept and come back as normal cards.
+DROP INDEX IF EXISTS idx_cards_deleted_at;
+ALTER TABLE Cards DROP COLUMN deletedAt;
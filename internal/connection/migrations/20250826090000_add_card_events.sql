@@ -0,0 +1,27 @@
+-- +goose Up
+-- CardEvents is an append-only log of the changes made to each card, shown as the card's
+-- history. Field changes keep the old and new values as text.
+CREATE TABLE CardEvents (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    cardId INTEGER NOT NULL,
+    eventType TEXT NOT NULL,
+    field TEXT,
+    oldValue TEXT,
+    newValue TEXT,
+    createdAt TIMESTAMP NOT NULL,
+    FOREIGN KEY (cardId) REFERENCES Cards(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_card_events_card_id ON CardEvents(cardId, createdAt);
+
+-- +goose StatementBegin
+CREATE TRIGGER card_events_append_only BEFORE UPDATE ON CardEvents
+BEGIN
+    SELECT RAISE(ABORT, 'card events cannot be changed');
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER IF EXISTS card_events_append_only;
+DROP INDEX IF EXISTS idx_card_events_card_id;
+DROP TABLE IF EXISTS CardEvents;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- A row means cardId is blocked by blockedById: the card should not be started or completed
+-- until the blocking card is done.
+CREATE TABLE CardDependencies (
+    cardId INTEGER NOT NULL,
+    blockedById INTEGER NOT NULL,
+    createdAt TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (cardId, blockedById),
+    CHECK (cardId <> blockedById),
+    FOREIGN KEY (cardId) REFERENCES Cards(id) ON DELETE CASCADE,
+    FOREIGN KEY (blockedById) REFERENCES Cards(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_card_dependencies_blocked_by_id ON CardDependencies(blockedById);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_card_dependencies_blocked_by_id;
+DROP TABLE IF EXISTS CardDependencies;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- Blueprints saved by the user. The built-in blueprints ship with the app and are not stored
+-- here. definition holds the blueprint's skills and card templates as JSON.
+CREATE TABLE Blueprints (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+    description TEXT,
+    definition TEXT NOT NULL,
+    createdAt TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updatedAt TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS Blueprints;
@@ -0,0 +1,81 @@
+-- +goose Up
+-- Each project has its own workflow: an ordered list of statuses (the board's columns) and the
+-- transitions allowed between them. Cards in a status with isDone set count as done, and their
+-- status column is kept in step (0 open, 1 done) so lists and statistics work unchanged.
+CREATE TABLE WorkflowStatuses (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    projectId INTEGER NOT NULL,
+    name TEXT NOT NULL COLLATE NOCASE,
+    position INTEGER NOT NULL DEFAULT 0,
+    isDone BOOLEAN NOT NULL DEFAULT FALSE,
+    createdAt TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (projectId, name),
+    FOREIGN KEY (projectId) REFERENCES Projects(id) ON DELETE CASCADE
+);
+
+CREATE TABLE WorkflowTransitions (
+    fromStatusId INTEGER NOT NULL,
+    toStatusId INTEGER NOT NULL,
+    PRIMARY KEY (fromStatusId, toStatusId),
+    CHECK (fromStatusId <> toStatusId),
+    FOREIGN KEY (fromStatusId) REFERENCES WorkflowStatuses(id) ON DELETE CASCADE,
+    FOREIGN KEY (toStatusId) REFERENCES WorkflowStatuses(id) ON DELETE CASCADE
+);
+
+ALTER TABLE Cards ADD COLUMN workflowStatusId INTEGER DEFAULT NULL;
+
+CREATE INDEX idx_cards_workflow_status_id ON Cards(workflowStatusId);
+
+-- Every existing project gets the default workflow, with every transition allowed.
+INSERT INTO WorkflowStatuses (projectId, name, position, isDone)
+SELECT id, 'Backlog', 0, FALSE FROM Projects
+UNION ALL SELECT id, 'Doing', 1, FALSE FROM Projects
+UNION ALL SELECT id, 'Review', 2, FALSE FROM Projects
+UNION ALL SELECT id, 'Done', 3, TRUE FROM Projects;
+
+INSERT INTO WorkflowTransitions (fromStatusId, toStatusId)
+SELECT f.id, t.id FROM WorkflowStatuses f
+JOIN WorkflowStatuses t ON t.projectId = f.projectId AND t.id <> f.id;
+
+-- Open cards start in Backlog and done cards in Done.
+UPDATE Cards SET workflowStatusId = (
+    SELECT ws.id FROM WorkflowStatuses ws
+    WHERE ws.projectId = Cards.projectId AND ws.isDone = (Cards.status = 1)
+    ORDER BY ws.position, ws.id LIMIT 1
+);
+
+-- New cards, and cards moved to another project, are placed in the first status of their
+-- project that matches whether they are done.
+-- +goose StatementBegin
+CREATE TRIGGER cards_workflow_status_insert AFTER INSERT ON Cards
+WHEN new.workflowStatusId IS NULL
+BEGIN
+    UPDATE Cards SET workflowStatusId = (
+        SELECT ws.id FROM WorkflowStatuses ws
+        WHERE ws.projectId = new.projectId AND ws.isDone = (new.status = 1)
+        ORDER BY ws.position, ws.id LIMIT 1
+    )
+    WHERE id = new.id;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER cards_workflow_status_project AFTER UPDATE OF projectId ON Cards
+WHEN new.projectId <> old.projectId
+BEGIN
+    UPDATE Cards SET workflowStatusId = (
+        SELECT ws.id FROM WorkflowStatuses ws
+        WHERE ws.projectId = new.projectId AND ws.isDone = (new.status = 1)
+        ORDER BY ws.position, ws.id LIMIT 1
+    )
+    WHERE id = new.id;
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER IF EXISTS cards_workflow_status_project;
+DROP TRIGGER IF EXISTS cards_workflow_status_insert;
+DROP INDEX IF EXISTS idx_cards_workflow_status_id;
+ALTER TABLE Cards DROP COLUMN workflowStatusId;
+DROP TABLE IF EXISTS WorkflowTransitions;
+DROP TABLE IF EXISTS WorkflowStatuses;
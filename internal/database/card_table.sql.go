@@ -11,8 +11,34 @@ import (
 	"time"
 )
 
-const createCard = `-- name: CreateCard :exec
-INSERT INTO Cards (title, description, status, projectId, estimatedMins) VALUES (?, ?, ?, ?, ?)
+const clearCardDueReminder = `-- name: ClearCardDueReminder :exec
+UPDATE Cards SET dueReminderSentAt = NULL WHERE id = ?
+`
+
+func (q *Queries) ClearCardDueReminder(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, clearCardDueReminder, id)
+	return err
+}
+
+const countLaterOccurrences = `-- name: CountLaterOccurrences :one
+SELECT COUNT(*) FROM Cards WHERE recurrenceSeriesId = ? AND recurrenceIndex > ?
+`
+
+type CountLaterOccurrencesParams struct {
+	RecurrenceSeriesId sql.NullInt64 `json:"recurrenceSeriesId"`
+	RecurrenceIndex    int64         `json:"recurrenceIndex"`
+}
+
+func (q *Queries) CountLaterOccurrences(ctx context.Context, arg CountLaterOccurrencesParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countLaterOccurrences, arg.RecurrenceSeriesId, arg.RecurrenceIndex)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createCard = `-- name: CreateCard :one
+INSERT INTO Cards (title, description, status, projectId, estimatedMins, dueAt, scheduledFor, priority, rank) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id
 `
 
 type CreateCardParams struct {
@@ -21,17 +47,91 @@ type CreateCardParams struct {
 	Status        int64          `json:"status"`
 	Projectid     int64          `json:"projectid"`
 	Estimatedmins int64          `json:"estimatedmins"`
+	DueAt         sql.NullTime   `json:"dueAt"`
+	ScheduledFor  sql.NullTime   `json:"scheduledFor"`
+	Priority      int64          `json:"priority"`
+	Rank          float64        `json:"rank"`
 }
 
-func (q *Queries) CreateCard(ctx context.Context, arg CreateCardParams) error {
-	_, err := q.db.ExecContext(ctx, createCard,
+func (q *Queries) CreateCard(ctx context.Context, arg CreateCardParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createCard,
 		arg.Title,
 		arg.Description,
 		arg.Status,
 		arg.Projectid,
 		arg.Estimatedmins,
+		arg.DueAt,
+		arg.ScheduledFor,
+		arg.Priority,
+		arg.Rank,
 	)
-	return err
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createCardOccurrence = `-- name: CreateCardOccurrence :one
+INSERT INTO Cards (title, description, status, projectId, estimatedMins, dueAt, scheduledFor, recurrenceRule, recurrenceSeriesId, recurrenceIndex, priority, rank)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, title, description, createdat, updatedat, status, completedat, estimatedmins, trackedmins, isactive, projectid, trackedSeconds, dueAt, scheduledFor, dueReminderSentAt, recurrenceRule, recurrenceSeriesId, recurrenceIndex, priority, rank, deletedAt, workflowStatusId
+`
+
+type CreateCardOccurrenceParams struct {
+	Title              string         `json:"title"`
+	Description        sql.NullString `json:"description"`
+	Status             int64          `json:"status"`
+	Projectid          int64          `json:"projectid"`
+	Estimatedmins      int64          `json:"estimatedmins"`
+	DueAt              sql.NullTime   `json:"dueAt"`
+	ScheduledFor       sql.NullTime   `json:"scheduledFor"`
+	RecurrenceRule     sql.NullString `json:"recurrenceRule"`
+	RecurrenceSeriesId sql.NullInt64  `json:"recurrenceSeriesId"`
+	RecurrenceIndex    int64          `json:"recurrenceIndex"`
+	Priority           int64          `json:"priority"`
+	Rank               float64        `json:"rank"`
+}
+
+func (q *Queries) CreateCardOccurrence(ctx context.Context, arg CreateCardOccurrenceParams) (Card, error) {
+	row := q.db.QueryRowContext(ctx, createCardOccurrence,
+		arg.Title,
+		arg.Description,
+		arg.Status,
+		arg.Projectid,
+		arg.Estimatedmins,
+		arg.DueAt,
+		arg.ScheduledFor,
+		arg.RecurrenceRule,
+		arg.RecurrenceSeriesId,
+		arg.RecurrenceIndex,
+		arg.Priority,
+		arg.Rank,
+	)
+	var i Card
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.Createdat,
+		&i.Updatedat,
+		&i.Status,
+		&i.Completedat,
+		&i.Estimatedmins,
+		&i.Trackedmins,
+		&i.Isactive,
+		&i.Projectid,
+		&i.TrackedSeconds,
+		&i.DueAt,
+		&i.ScheduledFor,
+		&i.DueReminderSentAt,
+		&i.RecurrenceRule,
+		&i.RecurrenceSeriesId,
+		&i.RecurrenceIndex,
+		&i.Priority,
+		&i.Rank,
+		&i.DeletedAt,
+		&i.WorkflowStatusId,
+	)
+	return i, err
 }
 
 const createTimeEntry = `-- name: CreateTimeEntry :one
@@ -73,6 +173,51 @@ func (q *Queries) DeleteCard(ctx context.Context, arg DeleteCardParams) error {
 	return err
 }
 
+const deleteCardTagsForCard = `-- name: DeleteCardTagsForCard :exec
+DELETE FROM CardTags WHERE cardId = ?
+`
+
+func (q *Queries) DeleteCardTagsForCard(ctx context.Context, cardid int64) error {
+	_, err := q.db.ExecContext(ctx, deleteCardTagsForCard, cardid)
+	return err
+}
+
+const deleteChecklistItemsForCard = `-- name: DeleteChecklistItemsForCard :exec
+DELETE FROM ChecklistItems WHERE cardId = ?
+`
+
+func (q *Queries) DeleteChecklistItemsForCard(ctx context.Context, cardid int64) error {
+	_, err := q.db.ExecContext(ctx, deleteChecklistItemsForCard, cardid)
+	return err
+}
+
+const deleteTaskCompletionsForCard = `-- name: DeleteTaskCompletionsForCard :exec
+DELETE FROM TaskCompletions WHERE cardId = ?
+`
+
+func (q *Queries) DeleteTaskCompletionsForCard(ctx context.Context, cardid int64) error {
+	_, err := q.db.ExecContext(ctx, deleteTaskCompletionsForCard, cardid)
+	return err
+}
+
+const deleteTimeEntriesForCard = `-- name: DeleteTimeEntriesForCard :exec
+DELETE FROM TimeEntries WHERE cardId = ?
+`
+
+func (q *Queries) DeleteTimeEntriesForCard(ctx context.Context, cardid int64) error {
+	_, err := q.db.ExecContext(ctx, deleteTimeEntriesForCard, cardid)
+	return err
+}
+
+const deleteTimeEntryPausesForCard = `-- name: DeleteTimeEntryPausesForCard :exec
+DELETE FROM TimeEntryPauses WHERE timeEntryId IN (SELECT id FROM TimeEntries WHERE cardId = ?)
+`
+
+func (q *Queries) DeleteTimeEntryPausesForCard(ctx context.Context, cardid int64) error {
+	_, err := q.db.ExecContext(ctx, deleteTimeEntryPausesForCard, cardid)
+	return err
+}
+
 const getActiveCard = `-- name: GetActiveCard :one
 SELECT id, title, status, projectId FROM Cards WHERE isactive == true
 `
@@ -125,16 +270,28 @@ SELECT
     c.isactive,
     c.estimatedMins,
     c.trackedMins,
+    c.trackedSeconds,
     c.projectId,
+    c.dueAt,
+    c.scheduledFor,
+    c.recurrenceRule,
+    c.recurrenceSeriesId,
+    c.recurrenceIndex,
+    c.priority,
+    c.rank,
+    c.workflowStatusId,
     te.id AS time_entry_id,
     te.startTime,
-    te.endTime
+    te.endTime,
+    (SELECT COUNT(*) FROM ChecklistItems ci WHERE ci.cardId = c.id) AS checklist_total,
+    (SELECT COUNT(*) FROM ChecklistItems ci WHERE ci.cardId = c.id AND ci.isDone = 1) AS checklist_done,
+    (SELECT CAST(IFNULL(SUM(ci.isDone) * 100 / COUNT(*), 0) AS INTEGER) FROM ChecklistItems ci WHERE ci.cardId = c.id) AS checklist_progress
 FROM 
     Cards c
 LEFT JOIN 
     TimeEntries te ON c.id = te.cardId
 WHERE 
-    c.id = ? AND c.projectId = ?
+    c.id = ? AND c.projectId = ? AND c.deletedAt IS NULL
 `
 
 type GetCardParams struct {
@@ -143,20 +300,32 @@ type GetCardParams struct {
 }
 
 type GetCardRow struct {
-	CardID        int64          `json:"card_id"`
-	Title         string         `json:"title"`
-	Description   sql.NullString `json:"description"`
-	Createdat     sql.NullTime   `json:"createdat"`
-	Updatedat     sql.NullTime   `json:"updatedat"`
-	Status        int64          `json:"status"`
-	Completedat   sql.NullTime   `json:"completedat"`
-	Isactive      bool           `json:"isactive"`
-	Estimatedmins int64          `json:"estimatedmins"`
-	Trackedmins   int64          `json:"trackedmins"`
-	Projectid     int64          `json:"projectid"`
-	TimeEntryID   sql.NullInt64  `json:"time_entry_id"`
-	Starttime     sql.NullTime   `json:"starttime"`
-	Endtime       sql.NullTime   `json:"endtime"`
+	CardID             int64          `json:"card_id"`
+	Title              string         `json:"title"`
+	Description        sql.NullString `json:"description"`
+	Createdat          sql.NullTime   `json:"createdat"`
+	Updatedat          sql.NullTime   `json:"updatedat"`
+	Status             int64          `json:"status"`
+	Completedat        sql.NullTime   `json:"completedat"`
+	Isactive           bool           `json:"isactive"`
+	Estimatedmins      int64          `json:"estimatedmins"`
+	Trackedmins        int64          `json:"trackedmins"`
+	TrackedSeconds     int64          `json:"trackedSeconds"`
+	Projectid          int64          `json:"projectid"`
+	DueAt              sql.NullTime   `json:"dueAt"`
+	ScheduledFor       sql.NullTime   `json:"scheduledFor"`
+	RecurrenceRule     sql.NullString `json:"recurrenceRule"`
+	RecurrenceSeriesId sql.NullInt64  `json:"recurrenceSeriesId"`
+	RecurrenceIndex    int64          `json:"recurrenceIndex"`
+	Priority           int64          `json:"priority"`
+	Rank               float64        `json:"rank"`
+	WorkflowStatusId   sql.NullInt64  `json:"workflowStatusId"`
+	TimeEntryID        sql.NullInt64  `json:"time_entry_id"`
+	Starttime          sql.NullTime   `json:"starttime"`
+	Endtime            sql.NullTime   `json:"endtime"`
+	ChecklistTotal     int64          `json:"checklist_total"`
+	ChecklistDone      int64          `json:"checklist_done"`
+	ChecklistProgress  int64          `json:"checklist_progress"`
 }
 
 func (q *Queries) GetCard(ctx context.Context, arg GetCardParams) (GetCardRow, error) {
@@ -173,40 +342,206 @@ func (q *Queries) GetCard(ctx context.Context, arg GetCardParams) (GetCardRow, e
 		&i.Isactive,
 		&i.Estimatedmins,
 		&i.Trackedmins,
+		&i.TrackedSeconds,
 		&i.Projectid,
+		&i.DueAt,
+		&i.ScheduledFor,
+		&i.RecurrenceRule,
+		&i.RecurrenceSeriesId,
+		&i.RecurrenceIndex,
+		&i.Priority,
+		&i.Rank,
+		&i.WorkflowStatusId,
 		&i.TimeEntryID,
 		&i.Starttime,
 		&i.Endtime,
+		&i.ChecklistTotal,
+		&i.ChecklistDone,
+		&i.ChecklistProgress,
 	)
 	return i, err
 }
 
-const listCards = `-- name: ListCards :many
-SELECT id, title, description, createdat, updatedat, status, completedat, estimatedmins, trackedmins, isactive, projectid, id AS card_id FROM Cards WHERE projectId = ? AND status = ?
+const getMaxCardRank = `-- name: GetMaxCardRank :one
+SELECT CAST(IFNULL(MAX(rank), 0) AS REAL) AS max_rank FROM Cards WHERE projectId = ?
 `
 
-type ListCardsParams struct {
+func (q *Queries) GetMaxCardRank(ctx context.Context, projectid int64) (float64, error) {
+	row := q.db.QueryRowContext(ctx, getMaxCardRank, projectid)
+	var max_rank float64
+	err := row.Scan(&max_rank)
+	return max_rank, err
+}
+
+const getTrashedCard = `-- name: GetTrashedCard :one
+SELECT id, title, description, createdat, updatedat, status, completedat, estimatedmins, trackedmins, isactive, projectid, trackedSeconds, dueAt, scheduledFor, dueReminderSentAt, recurrenceRule, recurrenceSeriesId, recurrenceIndex, priority, rank, deletedAt, workflowStatusId FROM Cards WHERE id = ? AND projectId = ? AND deletedAt IS NOT NULL
+`
+
+type GetTrashedCardParams struct {
+	ID        int64 `json:"id"`
+	Projectid int64 `json:"projectid"`
+}
+
+func (q *Queries) GetTrashedCard(ctx context.Context, arg GetTrashedCardParams) (Card, error) {
+	row := q.db.QueryRowContext(ctx, getTrashedCard, arg.ID, arg.Projectid)
+	var i Card
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.Createdat,
+		&i.Updatedat,
+		&i.Status,
+		&i.Completedat,
+		&i.Estimatedmins,
+		&i.Trackedmins,
+		&i.Isactive,
+		&i.Projectid,
+		&i.TrackedSeconds,
+		&i.DueAt,
+		&i.ScheduledFor,
+		&i.DueReminderSentAt,
+		&i.RecurrenceRule,
+		&i.RecurrenceSeriesId,
+		&i.RecurrenceIndex,
+		&i.Priority,
+		&i.Rank,
+		&i.DeletedAt,
+		&i.WorkflowStatusId,
+	)
+	return i, err
+}
+
+const listActiveTimeEntries = `-- name: ListActiveTimeEntries :many
+SELECT c.id AS card_id, c.title, c.projectId, te.id AS time_entry_id, te.startTime
+FROM Cards c
+JOIN TimeEntries te ON te.cardId = c.id AND te.startTime == te.endTime
+WHERE c.isactive == true
+`
+
+type ListActiveTimeEntriesRow struct {
+	CardID      int64     `json:"card_id"`
+	Title       string    `json:"title"`
+	Projectid   int64     `json:"projectid"`
+	TimeEntryID int64     `json:"time_entry_id"`
+	Starttime   time.Time `json:"starttime"`
+}
+
+func (q *Queries) ListActiveTimeEntries(ctx context.Context) ([]ListActiveTimeEntriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveTimeEntries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListActiveTimeEntriesRow
+	for rows.Next() {
+		var i ListActiveTimeEntriesRow
+		if err := rows.Scan(
+			&i.CardID,
+			&i.Title,
+			&i.Projectid,
+			&i.TimeEntryID,
+			&i.Starttime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCardRanks = `-- name: ListCardRanks :many
+SELECT id, rank FROM Cards WHERE projectId = ? AND status = ? AND deletedAt IS NULL ORDER BY rank, id
+`
+
+type ListCardRanksParams struct {
 	Projectid int64 `json:"projectid"`
 	Status    int64 `json:"status"`
 }
 
+type ListCardRanksRow struct {
+	ID   int64   `json:"id"`
+	Rank float64 `json:"rank"`
+}
+
+func (q *Queries) ListCardRanks(ctx context.Context, arg ListCardRanksParams) ([]ListCardRanksRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCardRanks, arg.Projectid, arg.Status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCardRanksRow
+	for rows.Next() {
+		var i ListCardRanksRow
+		if err := rows.Scan(&i.ID, &i.Rank); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCards = `-- name: ListCards :many
+SELECT id, title, description, createdat, updatedat, status, completedat, estimatedmins, trackedmins, isactive, projectid, trackedSeconds, dueAt, scheduledFor, dueReminderSentAt, recurrenceRule, recurrenceSeriesId, recurrenceIndex, priority, rank, deletedAt, workflowStatusId, id AS card_id,
+    (SELECT COUNT(*) FROM ChecklistItems ci WHERE ci.cardId = Cards.id) AS checklist_total,
+    (SELECT COUNT(*) FROM ChecklistItems ci WHERE ci.cardId = Cards.id AND ci.isDone = 1) AS checklist_done,
+    (SELECT CAST(IFNULL(SUM(ci.isDone) * 100 / COUNT(*), 0) AS INTEGER) FROM ChecklistItems ci WHERE ci.cardId = Cards.id) AS checklist_progress,
+    (SELECT CAST(COUNT(*) > 0 AS BOOLEAN) FROM CardDependencies d JOIN Cards b ON b.id = d.blockedById
+        WHERE d.cardId = Cards.id AND b.status <> ? AND b.deletedAt IS NULL) AS is_blocked
+FROM Cards WHERE projectId = ? AND status = ? AND deletedAt IS NULL
+ORDER BY rank, id
+`
+
+type ListCardsParams struct {
+	DoneStatus int64 `json:"done_status"`
+	Projectid  int64 `json:"projectid"`
+	Status     int64 `json:"status"`
+}
+
 type ListCardsRow struct {
-	ID            int64          `json:"id"`
-	Title         string         `json:"title"`
-	Description   sql.NullString `json:"description"`
-	Createdat     sql.NullTime   `json:"createdat"`
-	Updatedat     sql.NullTime   `json:"updatedat"`
-	Status        int64          `json:"status"`
-	Completedat   sql.NullTime   `json:"completedat"`
-	Estimatedmins int64          `json:"estimatedmins"`
-	Trackedmins   int64          `json:"trackedmins"`
-	Isactive      bool           `json:"isactive"`
-	Projectid     int64          `json:"projectid"`
-	CardID        int64          `json:"card_id"`
+	ID                 int64          `json:"id"`
+	Title              string         `json:"title"`
+	Description        sql.NullString `json:"description"`
+	Createdat          sql.NullTime   `json:"createdat"`
+	Updatedat          sql.NullTime   `json:"updatedat"`
+	Status             int64          `json:"status"`
+	Completedat        sql.NullTime   `json:"completedat"`
+	Estimatedmins      int64          `json:"estimatedmins"`
+	Trackedmins        int64          `json:"trackedmins"`
+	Isactive           bool           `json:"isactive"`
+	Projectid          int64          `json:"projectid"`
+	TrackedSeconds     int64          `json:"trackedSeconds"`
+	DueAt              sql.NullTime   `json:"dueAt"`
+	ScheduledFor       sql.NullTime   `json:"scheduledFor"`
+	DueReminderSentAt  sql.NullTime   `json:"dueReminderSentAt"`
+	RecurrenceRule     sql.NullString `json:"recurrenceRule"`
+	RecurrenceSeriesId sql.NullInt64  `json:"recurrenceSeriesId"`
+	RecurrenceIndex    int64          `json:"recurrenceIndex"`
+	Priority           int64          `json:"priority"`
+	Rank               float64        `json:"rank"`
+	DeletedAt          sql.NullTime   `json:"deletedAt"`
+	WorkflowStatusId   sql.NullInt64  `json:"workflowStatusId"`
+	CardID             int64          `json:"card_id"`
+	ChecklistTotal     int64          `json:"checklist_total"`
+	ChecklistDone      int64          `json:"checklist_done"`
+	ChecklistProgress  int64          `json:"checklist_progress"`
+	IsBlocked          bool           `json:"is_blocked"`
 }
 
 func (q *Queries) ListCards(ctx context.Context, arg ListCardsParams) ([]ListCardsRow, error) {
-	rows, err := q.db.QueryContext(ctx, listCards, arg.Projectid, arg.Status)
+	rows, err := q.db.QueryContext(ctx, listCards, arg.DoneStatus, arg.Projectid, arg.Status)
 	if err != nil {
 		return nil, err
 	}
@@ -226,7 +561,363 @@ func (q *Queries) ListCards(ctx context.Context, arg ListCardsParams) ([]ListCar
 			&i.Trackedmins,
 			&i.Isactive,
 			&i.Projectid,
+			&i.TrackedSeconds,
+			&i.DueAt,
+			&i.ScheduledFor,
+			&i.DueReminderSentAt,
+			&i.RecurrenceRule,
+			&i.RecurrenceSeriesId,
+			&i.RecurrenceIndex,
+			&i.Priority,
+			&i.Rank,
+			&i.DeletedAt,
+			&i.WorkflowStatusId,
+			&i.CardID,
+			&i.ChecklistTotal,
+			&i.ChecklistDone,
+			&i.ChecklistProgress,
+			&i.IsBlocked,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCardsDueForReminder = `-- name: ListCardsDueForReminder :many
+SELECT c.id, c.title, c.description, c.createdat, c.updatedat, c.status, c.completedat, c.estimatedmins, c.trackedmins, c.isactive, c.projectid, c.trackedSeconds, c.dueAt, c.scheduledFor, c.dueReminderSentAt, c.recurrenceRule, c.recurrenceSeriesId, c.recurrenceIndex, c.priority, c.rank, c.deletedAt, c.workflowStatusId FROM Cards c
+JOIN Projects p ON p.id = c.projectId
+WHERE c.status = ? AND p.archivedAt IS NULL AND c.deletedAt IS NULL AND c.dueReminderSentAt IS NULL
+AND c.dueAt > ? AND c.dueAt <= ?
+ORDER BY c.dueAt
+`
+
+type ListCardsDueForReminderParams struct {
+	Status      int64        `json:"status"`
+	Now         sql.NullTime `json:"now"`
+	RemindUntil sql.NullTime `json:"remind_until"`
+}
+
+func (q *Queries) ListCardsDueForReminder(ctx context.Context, arg ListCardsDueForReminderParams) ([]Card, error) {
+	rows, err := q.db.QueryContext(ctx, listCardsDueForReminder, arg.Status, arg.Now, arg.RemindUntil)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Card
+	for rows.Next() {
+		var i Card
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Createdat,
+			&i.Updatedat,
+			&i.Status,
+			&i.Completedat,
+			&i.Estimatedmins,
+			&i.Trackedmins,
+			&i.Isactive,
+			&i.Projectid,
+			&i.TrackedSeconds,
+			&i.DueAt,
+			&i.ScheduledFor,
+			&i.DueReminderSentAt,
+			&i.RecurrenceRule,
+			&i.RecurrenceSeriesId,
+			&i.RecurrenceIndex,
+			&i.Priority,
+			&i.Rank,
+			&i.DeletedAt,
+			&i.WorkflowStatusId,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCardsTrashedBefore = `-- name: ListCardsTrashedBefore :many
+SELECT id, title, description, createdat, updatedat, status, completedat, estimatedmins, trackedmins, isactive, projectid, trackedSeconds, dueAt, scheduledFor, dueReminderSentAt, recurrenceRule, recurrenceSeriesId, recurrenceIndex, priority, rank, deletedAt, workflowStatusId FROM Cards WHERE deletedAt IS NOT NULL AND deletedAt < ?
+`
+
+func (q *Queries) ListCardsTrashedBefore(ctx context.Context, deletedat sql.NullTime) ([]Card, error) {
+	rows, err := q.db.QueryContext(ctx, listCardsTrashedBefore, deletedat)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Card
+	for rows.Next() {
+		var i Card
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Createdat,
+			&i.Updatedat,
+			&i.Status,
+			&i.Completedat,
+			&i.Estimatedmins,
+			&i.Trackedmins,
+			&i.Isactive,
+			&i.Projectid,
+			&i.TrackedSeconds,
+			&i.DueAt,
+			&i.ScheduledFor,
+			&i.DueReminderSentAt,
+			&i.RecurrenceRule,
+			&i.RecurrenceSeriesId,
+			&i.RecurrenceIndex,
+			&i.Priority,
+			&i.Rank,
+			&i.DeletedAt,
+			&i.WorkflowStatusId,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOverdueCards = `-- name: ListOverdueCards :many
+SELECT c.id, c.title, c.description, c.createdat, c.updatedat, c.status, c.completedat, c.estimatedmins, c.trackedmins, c.isactive, c.projectid, c.trackedSeconds, c.dueAt, c.scheduledFor, c.dueReminderSentAt, c.recurrenceRule, c.recurrenceSeriesId, c.recurrenceIndex, c.priority, c.rank, c.deletedAt, c.workflowStatusId FROM Cards c
+JOIN Projects p ON p.id = c.projectId
+WHERE c.status = ? AND p.archivedAt IS NULL AND c.deletedAt IS NULL AND c.dueAt < ?
+ORDER BY c.dueAt
+`
+
+type ListOverdueCardsParams struct {
+	Status int64        `json:"status"`
+	Now    sql.NullTime `json:"now"`
+}
+
+func (q *Queries) ListOverdueCards(ctx context.Context, arg ListOverdueCardsParams) ([]Card, error) {
+	rows, err := q.db.QueryContext(ctx, listOverdueCards, arg.Status, arg.Now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Card
+	for rows.Next() {
+		var i Card
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Createdat,
+			&i.Updatedat,
+			&i.Status,
+			&i.Completedat,
+			&i.Estimatedmins,
+			&i.Trackedmins,
+			&i.Isactive,
+			&i.Projectid,
+			&i.TrackedSeconds,
+			&i.DueAt,
+			&i.ScheduledFor,
+			&i.DueReminderSentAt,
+			&i.RecurrenceRule,
+			&i.RecurrenceSeriesId,
+			&i.RecurrenceIndex,
+			&i.Priority,
+			&i.Rank,
+			&i.DeletedAt,
+			&i.WorkflowStatusId,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPlannedCards = `-- name: ListPlannedCards :many
+SELECT c.id, c.title, c.description, c.createdat, c.updatedat, c.status, c.completedat, c.estimatedmins, c.trackedmins, c.isactive, c.projectid, c.trackedSeconds, c.dueAt, c.scheduledFor, c.dueReminderSentAt, c.recurrenceRule, c.recurrenceSeriesId, c.recurrenceIndex, c.priority, c.rank, c.deletedAt, c.workflowStatusId FROM Cards c
+JOIN Projects p ON p.id = c.projectId
+WHERE c.status = ? AND p.archivedAt IS NULL AND c.deletedAt IS NULL
+AND ((c.dueAt >= ? AND c.dueAt < ?)
+    OR (c.scheduledFor >= ? AND c.scheduledFor < ?))
+ORDER BY COALESCE(c.scheduledFor, c.dueAt)
+`
+
+type ListPlannedCardsParams struct {
+	Status        int64        `json:"status"`
+	DueFrom       sql.NullTime `json:"due_from"`
+	DueTo         sql.NullTime `json:"due_to"`
+	ScheduledFrom sql.NullTime `json:"scheduled_from"`
+	ScheduledTo   sql.NullTime `json:"scheduled_to"`
+}
+
+func (q *Queries) ListPlannedCards(ctx context.Context, arg ListPlannedCardsParams) ([]Card, error) {
+	rows, err := q.db.QueryContext(ctx, listPlannedCards,
+		arg.Status,
+		arg.DueFrom,
+		arg.DueTo,
+		arg.ScheduledFrom,
+		arg.ScheduledTo,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Card
+	for rows.Next() {
+		var i Card
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Createdat,
+			&i.Updatedat,
+			&i.Status,
+			&i.Completedat,
+			&i.Estimatedmins,
+			&i.Trackedmins,
+			&i.Isactive,
+			&i.Projectid,
+			&i.TrackedSeconds,
+			&i.DueAt,
+			&i.ScheduledFor,
+			&i.DueReminderSentAt,
+			&i.RecurrenceRule,
+			&i.RecurrenceSeriesId,
+			&i.RecurrenceIndex,
+			&i.Priority,
+			&i.Rank,
+			&i.DeletedAt,
+			&i.WorkflowStatusId,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecurrenceHistory = `-- name: ListRecurrenceHistory :many
+SELECT c.id AS card_id, c.recurrenceIndex, c.status, c.dueAt, c.scheduledFor, c.completedAt, tc.totalExp
+FROM Cards c
+LEFT JOIN TaskCompletions tc ON tc.cardId = c.id AND tc.userId = ? AND tc.checklistItemId IS NULL
+WHERE c.recurrenceSeriesId = ? AND c.deletedAt IS NULL
+ORDER BY c.recurrenceIndex
+`
+
+type ListRecurrenceHistoryParams struct {
+	Userid             int64         `json:"userid"`
+	RecurrenceSeriesId sql.NullInt64 `json:"recurrenceSeriesId"`
+}
+
+type ListRecurrenceHistoryRow struct {
+	CardID          int64         `json:"card_id"`
+	RecurrenceIndex int64         `json:"recurrenceIndex"`
+	Status          int64         `json:"status"`
+	DueAt           sql.NullTime  `json:"dueAt"`
+	ScheduledFor    sql.NullTime  `json:"scheduledFor"`
+	Completedat     sql.NullTime  `json:"completedat"`
+	Totalexp        sql.NullInt64 `json:"totalexp"`
+}
+
+func (q *Queries) ListRecurrenceHistory(ctx context.Context, arg ListRecurrenceHistoryParams) ([]ListRecurrenceHistoryRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRecurrenceHistory, arg.Userid, arg.RecurrenceSeriesId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRecurrenceHistoryRow
+	for rows.Next() {
+		var i ListRecurrenceHistoryRow
+		if err := rows.Scan(
 			&i.CardID,
+			&i.RecurrenceIndex,
+			&i.Status,
+			&i.DueAt,
+			&i.ScheduledFor,
+			&i.Completedat,
+			&i.Totalexp,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTrashedCards = `-- name: ListTrashedCards :many
+SELECT id, title, description, createdat, updatedat, status, completedat, estimatedmins, trackedmins, isactive, projectid, trackedSeconds, dueAt, scheduledFor, dueReminderSentAt, recurrenceRule, recurrenceSeriesId, recurrenceIndex, priority, rank, deletedAt, workflowStatusId FROM Cards WHERE deletedAt IS NOT NULL ORDER BY deletedAt DESC, id
+`
+
+func (q *Queries) ListTrashedCards(ctx context.Context) ([]Card, error) {
+	rows, err := q.db.QueryContext(ctx, listTrashedCards)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Card
+	for rows.Next() {
+		var i Card
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Createdat,
+			&i.Updatedat,
+			&i.Status,
+			&i.Completedat,
+			&i.Estimatedmins,
+			&i.Trackedmins,
+			&i.Isactive,
+			&i.Projectid,
+			&i.TrackedSeconds,
+			&i.DueAt,
+			&i.ScheduledFor,
+			&i.DueReminderSentAt,
+			&i.RecurrenceRule,
+			&i.RecurrenceSeriesId,
+			&i.RecurrenceIndex,
+			&i.Priority,
+			&i.Rank,
+			&i.DeletedAt,
+			&i.WorkflowStatusId,
 		); err != nil {
 			return nil, err
 		}
@@ -241,6 +932,55 @@ func (q *Queries) ListCards(ctx context.Context, arg ListCardsParams) ([]ListCar
 	return items, nil
 }
 
+const markCardDueReminderSent = `-- name: MarkCardDueReminderSent :exec
+UPDATE Cards SET dueReminderSentAt = ? WHERE id = ?
+`
+
+type MarkCardDueReminderSentParams struct {
+	DueReminderSentAt sql.NullTime `json:"dueReminderSentAt"`
+	ID                int64        `json:"id"`
+}
+
+func (q *Queries) MarkCardDueReminderSent(ctx context.Context, arg MarkCardDueReminderSentParams) error {
+	_, err := q.db.ExecContext(ctx, markCardDueReminderSent, arg.DueReminderSentAt, arg.ID)
+	return err
+}
+
+const recalculateCardTrackedTime = `-- name: RecalculateCardTrackedTime :exec
+UPDATE Cards
+SET trackedSeconds = (SELECT IFNULL(SUM(te.duration), 0) FROM TimeEntries te WHERE te.cardId = Cards.id),
+    trackedMins = (SELECT IFNULL(SUM(te.duration), 0) FROM TimeEntries te WHERE te.cardId = Cards.id) / 60
+WHERE id = ?
+`
+
+func (q *Queries) RecalculateCardTrackedTime(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, recalculateCardTrackedTime, id)
+	return err
+}
+
+const restoreCard = `-- name: RestoreCard :exec
+UPDATE Cards SET deletedAt = NULL WHERE id = ?
+`
+
+func (q *Queries) RestoreCard(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, restoreCard, id)
+	return err
+}
+
+const trashCard = `-- name: TrashCard :exec
+UPDATE Cards SET deletedAt = ? WHERE id = ?
+`
+
+type TrashCardParams struct {
+	DeletedAt sql.NullTime `json:"deletedAt"`
+	ID        int64        `json:"id"`
+}
+
+func (q *Queries) TrashCard(ctx context.Context, arg TrashCardParams) error {
+	_, err := q.db.ExecContext(ctx, trashCard, arg.DeletedAt, arg.ID)
+	return err
+}
+
 const updateActiveTimeEntry = `-- name: UpdateActiveTimeEntry :exec
 UPDATE TimeEntries SET endTime = ?, duration = ? WHERE id = ?
 `
@@ -257,7 +997,7 @@ func (q *Queries) UpdateActiveTimeEntry(ctx context.Context, arg UpdateActiveTim
 }
 
 const updateCard = `-- name: UpdateCard :exec
-UPDATE Cards SET title = ?, description = ?, status = ?, completedAt = ?, estimatedMins = ?, trackedMins = ? WHERE id = ?
+UPDATE Cards SET title = ?, description = ?, status = ?, completedAt = ?, estimatedMins = ?, trackedMins = ?, updatedAt = CURRENT_TIMESTAMP WHERE id = ?
 `
 
 type UpdateCardParams struct {
@@ -284,16 +1024,115 @@ func (q *Queries) UpdateCard(ctx context.Context, arg UpdateCardParams) error {
 }
 
 const updateCardActive = `-- name: UpdateCardActive :exec
-UPDATE Cards SET isactive = ?, trackedMins = ? WHERE id = ?
+UPDATE Cards SET isactive = ?, trackedMins = ?, trackedSeconds = ? WHERE id = ?
 `
 
 type UpdateCardActiveParams struct {
-	Isactive    bool  `json:"isactive"`
-	Trackedmins int64 `json:"trackedmins"`
-	ID          int64 `json:"id"`
+	Isactive       bool  `json:"isactive"`
+	Trackedmins    int64 `json:"trackedmins"`
+	TrackedSeconds int64 `json:"trackedSeconds"`
+	ID             int64 `json:"id"`
 }
 
 func (q *Queries) UpdateCardActive(ctx context.Context, arg UpdateCardActiveParams) error {
-	_, err := q.db.ExecContext(ctx, updateCardActive, arg.Isactive, arg.Trackedmins, arg.ID)
+	_, err := q.db.ExecContext(ctx, updateCardActive,
+		arg.Isactive,
+		arg.Trackedmins,
+		arg.TrackedSeconds,
+		arg.ID,
+	)
+	return err
+}
+
+const updateCardPriority = `-- name: UpdateCardPriority :exec
+UPDATE Cards SET priority = ?, updatedAt = CURRENT_TIMESTAMP WHERE id = ?
+`
+
+type UpdateCardPriorityParams struct {
+	Priority int64 `json:"priority"`
+	ID       int64 `json:"id"`
+}
+
+func (q *Queries) UpdateCardPriority(ctx context.Context, arg UpdateCardPriorityParams) error {
+	_, err := q.db.ExecContext(ctx, updateCardPriority, arg.Priority, arg.ID)
+	return err
+}
+
+const updateCardProject = `-- name: UpdateCardProject :exec
+UPDATE Cards SET projectId = ?, rank = ?, updatedAt = CURRENT_TIMESTAMP WHERE id = ?
+`
+
+type UpdateCardProjectParams struct {
+	Projectid int64   `json:"projectid"`
+	Rank      float64 `json:"rank"`
+	ID        int64   `json:"id"`
+}
+
+func (q *Queries) UpdateCardProject(ctx context.Context, arg UpdateCardProjectParams) error {
+	_, err := q.db.ExecContext(ctx, updateCardProject, arg.Projectid, arg.Rank, arg.ID)
+	return err
+}
+
+const updateCardRank = `-- name: UpdateCardRank :exec
+UPDATE Cards SET rank = ? WHERE id = ?
+`
+
+type UpdateCardRankParams struct {
+	Rank float64 `json:"rank"`
+	ID   int64   `json:"id"`
+}
+
+func (q *Queries) UpdateCardRank(ctx context.Context, arg UpdateCardRankParams) error {
+	_, err := q.db.ExecContext(ctx, updateCardRank, arg.Rank, arg.ID)
+	return err
+}
+
+const updateCardRecurrence = `-- name: UpdateCardRecurrence :exec
+UPDATE Cards SET recurrenceRule = ?, recurrenceSeriesId = ?, recurrenceIndex = ? WHERE id = ?
+`
+
+type UpdateCardRecurrenceParams struct {
+	RecurrenceRule     sql.NullString `json:"recurrenceRule"`
+	RecurrenceSeriesId sql.NullInt64  `json:"recurrenceSeriesId"`
+	RecurrenceIndex    int64          `json:"recurrenceIndex"`
+	ID                 int64          `json:"id"`
+}
+
+func (q *Queries) UpdateCardRecurrence(ctx context.Context, arg UpdateCardRecurrenceParams) error {
+	_, err := q.db.ExecContext(ctx, updateCardRecurrence,
+		arg.RecurrenceRule,
+		arg.RecurrenceSeriesId,
+		arg.RecurrenceIndex,
+		arg.ID,
+	)
+	return err
+}
+
+const updateCardSchedule = `-- name: UpdateCardSchedule :exec
+UPDATE Cards SET dueAt = ?, scheduledFor = ?, updatedAt = CURRENT_TIMESTAMP WHERE id = ?
+`
+
+type UpdateCardScheduleParams struct {
+	DueAt        sql.NullTime `json:"dueAt"`
+	ScheduledFor sql.NullTime `json:"scheduledFor"`
+	ID           int64        `json:"id"`
+}
+
+func (q *Queries) UpdateCardSchedule(ctx context.Context, arg UpdateCardScheduleParams) error {
+	_, err := q.db.ExecContext(ctx, updateCardSchedule, arg.DueAt, arg.ScheduledFor, arg.ID)
+	return err
+}
+
+const updateCardWorkflowStatus = `-- name: UpdateCardWorkflowStatus :exec
+UPDATE Cards SET workflowStatusId = ? WHERE id = ?
+`
+
+type UpdateCardWorkflowStatusParams struct {
+	WorkflowStatusId sql.NullInt64 `json:"workflowStatusId"`
+	ID               int64         `json:"id"`
+}
+
+func (q *Queries) UpdateCardWorkflowStatus(ctx context.Context, arg UpdateCardWorkflowStatusParams) error {
+	_, err := q.db.ExecContext(ctx, updateCardWorkflowStatus, arg.WorkflowStatusId, arg.ID)
 	return err
 }
@@ -9,6 +9,12 @@ import (
 	"time"
 )
 
+type AppHeartbeat struct {
+	ID             int64     `json:"id"`
+	Lastseenat     time.Time `json:"lastseenat"`
+	Stoppedcleanly bool      `json:"stoppedcleanly"`
+}
+
 type ArcherStat struct {
 	ID        int64  `json:"id"`
 	Userid    int64  `json:"userid"`
@@ -16,24 +22,97 @@ type ArcherStat struct {
 	Statvalue int64  `json:"statvalue"`
 }
 
+type Blueprint struct {
+	ID          int64          `json:"id"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	Definition  string         `json:"definition"`
+	Createdat   sql.NullTime   `json:"createdat"`
+	Updatedat   sql.NullTime   `json:"updatedat"`
+}
+
 type Card struct {
-	ID            int64          `json:"id"`
-	Title         string         `json:"title"`
-	Description   sql.NullString `json:"description"`
-	Createdat     sql.NullTime   `json:"createdat"`
-	Updatedat     sql.NullTime   `json:"updatedat"`
-	Status        int64          `json:"status"`
-	Completedat   sql.NullTime   `json:"completedat"`
-	Estimatedmins int64          `json:"estimatedmins"`
-	Trackedmins   int64          `json:"trackedmins"`
-	Isactive      bool           `json:"isactive"`
-	Projectid     int64          `json:"projectid"`
+	ID                 int64          `json:"id"`
+	Title              string         `json:"title"`
+	Description        sql.NullString `json:"description"`
+	Createdat          sql.NullTime   `json:"createdat"`
+	Updatedat          sql.NullTime   `json:"updatedat"`
+	Status             int64          `json:"status"`
+	Completedat        sql.NullTime   `json:"completedat"`
+	Estimatedmins      int64          `json:"estimatedmins"`
+	Trackedmins        int64          `json:"trackedmins"`
+	Isactive           bool           `json:"isactive"`
+	Projectid          int64          `json:"projectid"`
+	TrackedSeconds     int64          `json:"trackedSeconds"`
+	DueAt              sql.NullTime   `json:"dueAt"`
+	ScheduledFor       sql.NullTime   `json:"scheduledFor"`
+	DueReminderSentAt  sql.NullTime   `json:"dueReminderSentAt"`
+	RecurrenceRule     sql.NullString `json:"recurrenceRule"`
+	RecurrenceSeriesId sql.NullInt64  `json:"recurrenceSeriesId"`
+	RecurrenceIndex    int64          `json:"recurrenceIndex"`
+	Priority           int64          `json:"priority"`
+	Rank               float64        `json:"rank"`
+	DeletedAt          sql.NullTime   `json:"deletedAt"`
+	WorkflowStatusId   sql.NullInt64  `json:"workflowStatusId"`
+}
+
+type CardDependency struct {
+	Cardid      int64        `json:"cardid"`
+	Blockedbyid int64        `json:"blockedbyid"`
+	Createdat   sql.NullTime `json:"createdat"`
+}
+
+type CardEvent struct {
+	ID        int64          `json:"id"`
+	Cardid    int64          `json:"cardid"`
+	Eventtype string         `json:"eventtype"`
+	Field     sql.NullString `json:"field"`
+	Oldvalue  sql.NullString `json:"oldvalue"`
+	Newvalue  sql.NullString `json:"newvalue"`
+	Createdat time.Time      `json:"createdat"`
+}
+
+type CardTag struct {
+	Cardid int64 `json:"cardid"`
+	Tagid  int64 `json:"tagid"`
+}
+
+type ChecklistItem struct {
+	ID          int64        `json:"id"`
+	Cardid      int64        `json:"cardid"`
+	Title       string       `json:"title"`
+	Position    int64        `json:"position"`
+	Isdone      bool         `json:"isdone"`
+	Completedat sql.NullTime `json:"completedat"`
+	Createdat   sql.NullTime `json:"createdat"`
+}
+
+type FocusSession struct {
+	ID             int64          `json:"id"`
+	Cardid         int64          `json:"cardid"`
+	Projectid      int64          `json:"projectid"`
+	Phase          string         `json:"phase"`
+	Cycle          int64          `json:"cycle"`
+	Plannedseconds int64          `json:"plannedseconds"`
+	Startedat      time.Time      `json:"startedat"`
+	Endedat        sql.NullTime   `json:"endedat"`
+	Outcome        sql.NullString `json:"outcome"`
+	Interruptions  int64          `json:"interruptions"`
+}
+
+type LevelHistory struct {
+	ID        int64     `json:"id"`
+	Userid    int64     `json:"userid"`
+	Level     int64     `json:"level"`
+	Totalexp  int64     `json:"totalexp"`
+	Reachedat time.Time `json:"reachedat"`
 }
 
 type Project struct {
-	ID        int64        `json:"id"`
-	Name      string       `json:"name"`
-	Createdat sql.NullTime `json:"createdat"`
+	ID         int64        `json:"id"`
+	Name       string       `json:"name"`
+	Createdat  sql.NullTime `json:"createdat"`
+	ArchivedAt sql.NullTime `json:"archivedAt"`
 }
 
 type ProjectSkill struct {
@@ -41,15 +120,35 @@ type ProjectSkill struct {
 	SkillID   int64 `json:"skill_id"`
 }
 
+type Setting struct {
+	Key       string       `json:"key"`
+	Value     string       `json:"value"`
+	Updatedat sql.NullTime `json:"updatedat"`
+}
+
+type StreakRestDay struct {
+	Day       string         `json:"day"`
+	Note      sql.NullString `json:"note"`
+	Createdat sql.NullTime   `json:"createdat"`
+}
+
+type Tag struct {
+	ID        int64          `json:"id"`
+	Name      string         `json:"name"`
+	Color     sql.NullString `json:"color"`
+	Createdat sql.NullTime   `json:"createdat"`
+}
+
 type TaskCompletion struct {
-	ID             int64     `json:"id"`
-	Cardid         int64     `json:"cardid"`
-	Userid         int64     `json:"userid"`
-	Completiontime time.Time `json:"completiontime"`
-	Baseexp        int64     `json:"baseexp"`
-	Timebonusexp   int64     `json:"timebonusexp"`
-	Streakbonusexp int64     `json:"streakbonusexp"`
-	Totalexp       int64     `json:"totalexp"`
+	ID              int64         `json:"id"`
+	Cardid          int64         `json:"cardid"`
+	Userid          int64         `json:"userid"`
+	Completiontime  time.Time     `json:"completiontime"`
+	Baseexp         int64         `json:"baseexp"`
+	Timebonusexp    int64         `json:"timebonusexp"`
+	Streakbonusexp  int64         `json:"streakbonusexp"`
+	Totalexp        int64         `json:"totalexp"`
+	ChecklistItemId sql.NullInt64 `json:"checklistItemId"`
 }
 
 type TimeEntry struct {
@@ -60,6 +159,13 @@ type TimeEntry struct {
 	Duration  int64     `json:"duration"`
 }
 
+type TimeEntryPause struct {
+	ID          int64        `json:"id"`
+	Timeentryid int64        `json:"timeentryid"`
+	Pausedat    time.Time    `json:"pausedat"`
+	Resumedat   sql.NullTime `json:"resumedat"`
+}
+
 type UserProfile struct {
 	ID                 int64        `json:"id"`
 	Name               string       `json:"name"`
@@ -88,4 +194,19 @@ type UserSkillProgress struct {
 	SkillID             int64         `json:"skill_id"`
 	TotalMinutesTracked sql.NullInt64 `json:"total_minutes_tracked"`
 	LastUpdated         sql.NullTime  `json:"last_updated"`
+	TotalSecondsTracked int64         `json:"total_seconds_tracked"`
+}
+
+type WorkflowStatus struct {
+	ID        int64        `json:"id"`
+	Projectid int64        `json:"projectid"`
+	Name      string       `json:"name"`
+	Position  int64        `json:"position"`
+	Isdone    bool         `json:"isdone"`
+	Createdat sql.NullTime `json:"createdat"`
+}
+
+type WorkflowTransition struct {
+	Fromstatusid int64 `json:"fromstatusid"`
+	Tostatusid   int64 `json:"tostatusid"`
 }
@@ -11,77 +11,78 @@ import (
 )
 
 const aggregateMonthHours = `-- name: AggregateMonthHours :one
-SELECT CAST(IFNULL(SUM(te.duration), 0) AS FLOAT) AS totalTrackedMinsCurrentMonth
+SELECT CAST(IFNULL(SUM(te.duration), 0) AS FLOAT) AS totalTrackedSecondsCurrentMonth
 FROM TimeEntries te
 JOIN Cards c ON te.cardId = c.id
 JOIN Projects p ON c.projectId = p.id
-WHERE p.id = ?
+WHERE p.id = ? AND c.deletedAt IS NULL
 AND strftime('%Y-%m', te.startTime) = strftime('%Y-%m', 'now')
 `
 
 func (q *Queries) AggregateMonthHours(ctx context.Context, id int64) (float64, error) {
 	row := q.db.QueryRowContext(ctx, aggregateMonthHours, id)
-	var totaltrackedminscurrentmonth float64
-	err := row.Scan(&totaltrackedminscurrentmonth)
-	return totaltrackedminscurrentmonth, err
+	var totaltrackedsecondscurrentmonth float64
+	err := row.Scan(&totaltrackedsecondscurrentmonth)
+	return totaltrackedsecondscurrentmonth, err
 }
 
 const aggregatePreviousMonthHours = `-- name: AggregatePreviousMonthHours :one
-SELECT CAST(IFNULL(SUM(te.duration), 0) AS FLOAT) AS totalTrackedMinsPreviousMonth
+SELECT CAST(IFNULL(SUM(te.duration), 0) AS FLOAT) AS totalTrackedSecondsPreviousMonth
 FROM TimeEntries te
 JOIN Cards c ON te.cardId = c.id
 JOIN Projects p ON c.projectId = p.id
-WHERE p.id = ?
+WHERE p.id = ? AND c.deletedAt IS NULL
 AND strftime('%Y-%m', te.startTime) = strftime('%Y-%m', 'now', 'start of month', '-1 month')
 `
 
 func (q *Queries) AggregatePreviousMonthHours(ctx context.Context, id int64) (float64, error) {
 	row := q.db.QueryRowContext(ctx, aggregatePreviousMonthHours, id)
-	var totaltrackedminspreviousmonth float64
-	err := row.Scan(&totaltrackedminspreviousmonth)
-	return totaltrackedminspreviousmonth, err
+	var totaltrackedsecondspreviousmonth float64
+	err := row.Scan(&totaltrackedsecondspreviousmonth)
+	return totaltrackedsecondspreviousmonth, err
 }
 
 const aggregatePreviousWeekHours = `-- name: AggregatePreviousWeekHours :one
-SELECT CAST(IFNULL(SUM(te.duration), 0) AS FLOAT) AS totalTrackedMinsPreviousWeek
+SELECT CAST(IFNULL(SUM(te.duration), 0) AS FLOAT) AS totalTrackedSecondsPreviousWeek
 FROM TimeEntries te
 JOIN Cards c ON te.cardId = c.id
 JOIN Projects p ON c.projectId = p.id
-WHERE p.id = ?
+WHERE p.id = ? AND c.deletedAt IS NULL
 AND strftime('%Y-%W', te.startTime) = strftime('%Y-%W', 'now', '-7 days')
 `
 
 func (q *Queries) AggregatePreviousWeekHours(ctx context.Context, id int64) (float64, error) {
 	row := q.db.QueryRowContext(ctx, aggregatePreviousWeekHours, id)
-	var totaltrackedminspreviousweek float64
-	err := row.Scan(&totaltrackedminspreviousweek)
-	return totaltrackedminspreviousweek, err
+	var totaltrackedsecondspreviousweek float64
+	err := row.Scan(&totaltrackedsecondspreviousweek)
+	return totaltrackedsecondspreviousweek, err
 }
 
 const aggregateWeekHours = `-- name: AggregateWeekHours :one
-SELECT CAST(IFNULL(SUM(te.duration), 0) AS FLOAT) AS totalTrackedMinsCurrentWeek
+SELECT CAST(IFNULL(SUM(te.duration), 0) AS FLOAT) AS totalTrackedSecondsCurrentWeek
 FROM TimeEntries te
 JOIN Cards c ON te.cardId = c.id
 JOIN Projects p ON c.projectId = p.id
-WHERE p.id = ?
+WHERE p.id = ? AND c.deletedAt IS NULL
 AND strftime('%Y-%W', te.startTime) = strftime('%Y-%W', 'now')
 `
 
 func (q *Queries) AggregateWeekHours(ctx context.Context, id int64) (float64, error) {
 	row := q.db.QueryRowContext(ctx, aggregateWeekHours, id)
-	var totaltrackedminscurrentweek float64
-	err := row.Scan(&totaltrackedminscurrentweek)
-	return totaltrackedminscurrentweek, err
+	var totaltrackedsecondscurrentweek float64
+	err := row.Scan(&totaltrackedsecondscurrentweek)
+	return totaltrackedsecondscurrentweek, err
 }
 
 const getDailyTotalMinutes = `-- name: GetDailyTotalMinutes :many
 SELECT
     DATE(startTime) AS date,
-    SUM(duration) AS total_minutes
+    SUM(duration) / 60.0 AS total_minutes
 FROM
     TimeEntries
 WHERE
     startTime >= DATE('now', '-1 year')
+    AND cardId IN (SELECT id FROM Cards WHERE deletedAt IS NULL)
 GROUP BY
     DATE(startTime)
 ORDER BY
@@ -120,6 +121,7 @@ const getMonthlyProgress = `-- name: GetMonthlyProgress :one
 SELECT COUNT(DISTINCT DATE(startTime)) AS progress_days
 FROM TimeEntries
 WHERE strftime('%Y-%m', startTime) = strftime('%Y-%m', 'now')
+AND cardId IN (SELECT id FROM Cards WHERE deletedAt IS NULL)
 `
 
 func (q *Queries) GetMonthlyProgress(ctx context.Context) (int64, error) {
@@ -133,6 +135,7 @@ const getPreviousMonthlyProgress = `-- name: GetPreviousMonthlyProgress :one
 SELECT COUNT(DISTINCT DATE(startTime)) AS progress_days
 FROM TimeEntries
 WHERE strftime('%Y-%m', startTime) = strftime('%Y-%m', 'now', 'start of month', '-1 month')
+AND cardId IN (SELECT id FROM Cards WHERE deletedAt IS NULL)
 `
 
 func (q *Queries) GetPreviousMonthlyProgress(ctx context.Context) (int64, error) {
@@ -146,6 +149,7 @@ const getPreviousWeeklyProgress = `-- name: GetPreviousWeeklyProgress :one
 SELECT COUNT(DISTINCT DATE(startTime)) AS progress_days
 FROM TimeEntries
 WHERE strftime('%Y-%W', startTime) = strftime('%Y-%W', 'now', '-7 days')
+AND cardId IN (SELECT id FROM Cards WHERE deletedAt IS NULL)
 `
 
 func (q *Queries) GetPreviousWeeklyProgress(ctx context.Context) (int64, error) {
@@ -159,6 +163,7 @@ const getWeeklyProgress = `-- name: GetWeeklyProgress :one
 SELECT COUNT(DISTINCT DATE(startTime)) AS progress_days
 FROM TimeEntries
 WHERE strftime('%Y-%W', startTime) = strftime('%Y-%W', 'now')
+AND cardId IN (SELECT id FROM Cards WHERE deletedAt IS NULL)
 `
 
 func (q *Queries) GetWeeklyProgress(ctx context.Context) (int64, error) {
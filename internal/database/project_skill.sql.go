@@ -57,6 +57,15 @@ func (q *Queries) GetSkillsForProject(ctx context.Context, projectID int64) ([]U
 	return items, nil
 }
 
+const removeAllProjectSkills = `-- name: RemoveAllProjectSkills :exec
+DELETE FROM ProjectSkill WHERE project_id = ?
+`
+
+func (q *Queries) RemoveAllProjectSkills(ctx context.Context, projectID int64) error {
+	_, err := q.db.ExecContext(ctx, removeAllProjectSkills, projectID)
+	return err
+}
+
 const removeProjectSkill = `-- name: RemoveProjectSkill :exec
 DELETE FROM ProjectSkill WHERE project_id = ? AND skill_id = ?
 `
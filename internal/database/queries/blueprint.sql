@@ -0,0 +1,13 @@
+-- name: ListBlueprints :many
+SELECT * FROM Blueprints ORDER BY name;
+
+-- name: GetBlueprintByName :one
+SELECT * FROM Blueprints WHERE name = ? LIMIT 1;
+
+-- name: UpsertBlueprint :one
+INSERT INTO Blueprints (name, description, definition) VALUES (?, ?, ?)
+ON CONFLICT(name) DO UPDATE SET description = EXCLUDED.description, definition = EXCLUDED.definition, updatedAt = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: DeleteBlueprint :exec
+DELETE FROM Blueprints WHERE id = ?;
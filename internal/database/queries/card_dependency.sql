@@ -0,0 +1,33 @@
+-- name: AddCardDependency :exec
+INSERT OR IGNORE INTO CardDependencies (cardId, blockedById) VALUES (?, ?);
+
+-- name: RemoveCardDependency :exec
+DELETE FROM CardDependencies WHERE cardId = ? AND blockedById = ?;
+
+-- name: ListProjectCardDependencies :many
+SELECT d.* FROM CardDependencies d
+JOIN Cards c ON c.id = d.cardId
+WHERE c.projectId = ?;
+
+-- name: ListBlockingCards :many
+SELECT c.* FROM Cards c
+JOIN CardDependencies d ON d.blockedById = c.id
+WHERE d.cardId = ? AND c.deletedAt IS NULL
+ORDER BY c.rank, c.id;
+
+-- name: ListBlockedCards :many
+SELECT c.* FROM Cards c
+JOIN CardDependencies d ON d.cardId = c.id
+WHERE d.blockedById = ? AND c.deletedAt IS NULL
+ORDER BY c.rank, c.id;
+
+-- name: CountOpenBlockers :one
+SELECT COUNT(*) FROM CardDependencies d
+JOIN Cards c ON c.id = d.blockedById
+WHERE d.cardId = ? AND c.status <> ? AND c.deletedAt IS NULL;
+
+-- name: DeleteDependenciesOfCard :exec
+DELETE FROM CardDependencies WHERE cardId = ?;
+
+-- name: DeleteDependenciesOnCard :exec
+DELETE FROM CardDependencies WHERE blockedById = ?;
@@ -0,0 +1,8 @@
+-- name: CreateCardEvent :exec
+INSERT INTO CardEvents (cardId, eventType, field, oldValue, newValue, createdAt) VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: ListCardEvents :many
+SELECT * FROM CardEvents WHERE cardId = ? ORDER BY createdAt, id;
+
+-- name: DeleteCardEventsForCard :exec
+DELETE FROM CardEvents WHERE cardId = ?;
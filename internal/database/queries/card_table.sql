@@ -10,34 +10,150 @@ SELECT
     c.isactive,
     c.estimatedMins,
     c.trackedMins,
+    c.trackedSeconds,
     c.projectId,
+    c.dueAt,
+    c.scheduledFor,
+    c.recurrenceRule,
+    c.recurrenceSeriesId,
+    c.recurrenceIndex,
+    c.priority,
+    c.rank,
+    c.workflowStatusId,
     te.id AS time_entry_id,
     te.startTime,
-    te.endTime
+    te.endTime,
+    (SELECT COUNT(*) FROM ChecklistItems ci WHERE ci.cardId = c.id) AS checklist_total,
+    (SELECT COUNT(*) FROM ChecklistItems ci WHERE ci.cardId = c.id AND ci.isDone = 1) AS checklist_done,
+    (SELECT CAST(IFNULL(SUM(ci.isDone) * 100 / COUNT(*), 0) AS INTEGER) FROM ChecklistItems ci WHERE ci.cardId = c.id) AS checklist_progress
 FROM 
     Cards c
 LEFT JOIN 
     TimeEntries te ON c.id = te.cardId
 WHERE 
-    c.id = ? AND c.projectId = ?;
+    c.id = ? AND c.projectId = ? AND c.deletedAt IS NULL;
 
 -- name: ListCards :many
-SELECT *, id AS card_id FROM Cards WHERE projectId = ? AND status = ?;
+SELECT *, id AS card_id,
+    (SELECT COUNT(*) FROM ChecklistItems ci WHERE ci.cardId = Cards.id) AS checklist_total,
+    (SELECT COUNT(*) FROM ChecklistItems ci WHERE ci.cardId = Cards.id AND ci.isDone = 1) AS checklist_done,
+    (SELECT CAST(IFNULL(SUM(ci.isDone) * 100 / COUNT(*), 0) AS INTEGER) FROM ChecklistItems ci WHERE ci.cardId = Cards.id) AS checklist_progress,
+    (SELECT CAST(COUNT(*) > 0 AS BOOLEAN) FROM CardDependencies d JOIN Cards b ON b.id = d.blockedById
+        WHERE d.cardId = Cards.id AND b.status <> sqlc.arg(done_status) AND b.deletedAt IS NULL) AS is_blocked
+FROM Cards WHERE projectId = ? AND status = ? AND deletedAt IS NULL
+ORDER BY rank, id;
 
--- name: CreateCard :exec
-INSERT INTO Cards (title, description, status, projectId, estimatedMins) VALUES (?, ?, ?, ?, ?);
+-- name: CreateCard :one
+INSERT INTO Cards (title, description, status, projectId, estimatedMins, dueAt, scheduledFor, priority, rank) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id;
+
+-- name: CreateCardOccurrence :one
+INSERT INTO Cards (title, description, status, projectId, estimatedMins, dueAt, scheduledFor, recurrenceRule, recurrenceSeriesId, recurrenceIndex, priority, rank)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
 
 -- name: UpdateCard :exec
-UPDATE Cards SET title = ?, description = ?, status = ?, completedAt = ?, estimatedMins = ?, trackedMins = ? WHERE id = ?;
+UPDATE Cards SET title = ?, description = ?, status = ?, completedAt = ?, estimatedMins = ?, trackedMins = ?, updatedAt = CURRENT_TIMESTAMP WHERE id = ?;
+
+-- name: UpdateCardSchedule :exec
+UPDATE Cards SET dueAt = ?, scheduledFor = ?, updatedAt = CURRENT_TIMESTAMP WHERE id = ?;
+
+-- name: UpdateCardRecurrence :exec
+UPDATE Cards SET recurrenceRule = ?, recurrenceSeriesId = ?, recurrenceIndex = ? WHERE id = ?;
+
+-- name: CountLaterOccurrences :one
+SELECT COUNT(*) FROM Cards WHERE recurrenceSeriesId = ? AND recurrenceIndex > ?;
+
+-- name: ListRecurrenceHistory :many
+SELECT c.id AS card_id, c.recurrenceIndex, c.status, c.dueAt, c.scheduledFor, c.completedAt, tc.totalExp
+FROM Cards c
+LEFT JOIN TaskCompletions tc ON tc.cardId = c.id AND tc.userId = ? AND tc.checklistItemId IS NULL
+WHERE c.recurrenceSeriesId = ? AND c.deletedAt IS NULL
+ORDER BY c.recurrenceIndex;
+
+-- name: UpdateCardPriority :exec
+UPDATE Cards SET priority = ?, updatedAt = CURRENT_TIMESTAMP WHERE id = ?;
+
+-- name: GetMaxCardRank :one
+SELECT CAST(IFNULL(MAX(rank), 0) AS REAL) AS max_rank FROM Cards WHERE projectId = ?;
+
+-- name: ListCardRanks :many
+SELECT id, rank FROM Cards WHERE projectId = ? AND status = ? AND deletedAt IS NULL ORDER BY rank, id;
+
+-- name: UpdateCardRank :exec
+UPDATE Cards SET rank = ? WHERE id = ?;
+
+-- name: UpdateCardProject :exec
+UPDATE Cards SET projectId = ?, rank = ?, updatedAt = CURRENT_TIMESTAMP WHERE id = ?;
+
+-- name: UpdateCardWorkflowStatus :exec
+UPDATE Cards SET workflowStatusId = ? WHERE id = ?;
+
+-- name: ClearCardDueReminder :exec
+UPDATE Cards SET dueReminderSentAt = NULL WHERE id = ?;
+
+-- name: MarkCardDueReminderSent :exec
+UPDATE Cards SET dueReminderSentAt = ? WHERE id = ?;
+
+-- name: ListOverdueCards :many
+SELECT c.* FROM Cards c
+JOIN Projects p ON p.id = c.projectId
+WHERE c.status = ? AND p.archivedAt IS NULL AND c.deletedAt IS NULL AND c.dueAt < sqlc.arg(now)
+ORDER BY c.dueAt;
+
+-- name: ListPlannedCards :many
+SELECT c.* FROM Cards c
+JOIN Projects p ON p.id = c.projectId
+WHERE c.status = ? AND p.archivedAt IS NULL AND c.deletedAt IS NULL
+AND ((c.dueAt >= sqlc.arg(due_from) AND c.dueAt < sqlc.arg(due_to))
+    OR (c.scheduledFor >= sqlc.arg(scheduled_from) AND c.scheduledFor < sqlc.arg(scheduled_to)))
+ORDER BY COALESCE(c.scheduledFor, c.dueAt);
+
+-- name: ListCardsDueForReminder :many
+SELECT c.* FROM Cards c
+JOIN Projects p ON p.id = c.projectId
+WHERE c.status = ? AND p.archivedAt IS NULL AND c.deletedAt IS NULL AND c.dueReminderSentAt IS NULL
+AND c.dueAt > sqlc.arg(now) AND c.dueAt <= sqlc.arg(remind_until)
+ORDER BY c.dueAt;
 
 -- name: DeleteCard :exec
 DELETE FROM Cards WHERE projectId = ? AND id = ?;
 
+-- name: TrashCard :exec
+UPDATE Cards SET deletedAt = ? WHERE id = ?;
+
+-- name: RestoreCard :exec
+UPDATE Cards SET deletedAt = NULL WHERE id = ?;
+
+-- name: GetTrashedCard :one
+SELECT * FROM Cards WHERE id = ? AND projectId = ? AND deletedAt IS NOT NULL;
+
+-- name: ListTrashedCards :many
+SELECT * FROM Cards WHERE deletedAt IS NOT NULL ORDER BY deletedAt DESC, id;
+
+-- name: ListCardsTrashedBefore :many
+SELECT * FROM Cards WHERE deletedAt IS NOT NULL AND deletedAt < ?;
+
+-- name: DeleteTimeEntryPausesForCard :exec
+DELETE FROM TimeEntryPauses WHERE timeEntryId IN (SELECT id FROM TimeEntries WHERE cardId = ?);
+
+-- name: DeleteTimeEntriesForCard :exec
+DELETE FROM TimeEntries WHERE cardId = ?;
+
+-- name: DeleteTaskCompletionsForCard :exec
+DELETE FROM TaskCompletions WHERE cardId = ?;
+
+-- name: DeleteChecklistItemsForCard :exec
+DELETE FROM ChecklistItems WHERE cardId = ?;
+
+-- name: DeleteCardTagsForCard :exec
+DELETE FROM CardTags WHERE cardId = ?;
+
 -- name: GetActiveCard :one
 SELECT id, title, status, projectId FROM Cards WHERE isactive == true;
 
 -- name: UpdateCardActive :exec
-UPDATE Cards SET isactive = ?, trackedMins = ? WHERE id = ?;
+UPDATE Cards SET isactive = ?, trackedMins = ?, trackedSeconds = ? WHERE id = ?;
 
 -- name: CreateTimeEntry :one
 INSERT INTO TimeEntries (cardId, startTime, endTime) 
@@ -49,3 +165,15 @@ RETURNING *;
 
 -- name: UpdateActiveTimeEntry :exec
 UPDATE TimeEntries SET endTime = ?, duration = ? WHERE id = ?;
+
+-- name: RecalculateCardTrackedTime :exec
+UPDATE Cards
+SET trackedSeconds = (SELECT IFNULL(SUM(te.duration), 0) FROM TimeEntries te WHERE te.cardId = Cards.id),
+    trackedMins = (SELECT IFNULL(SUM(te.duration), 0) FROM TimeEntries te WHERE te.cardId = Cards.id) / 60
+WHERE id = ?;
+
+-- name: ListActiveTimeEntries :many
+SELECT c.id AS card_id, c.title, c.projectId, te.id AS time_entry_id, te.startTime
+FROM Cards c
+JOIN TimeEntries te ON te.cardId = c.id AND te.startTime == te.endTime
+WHERE c.isactive == true;
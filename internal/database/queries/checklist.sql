@@ -0,0 +1,19 @@
+-- name: ListChecklistItems :many
+SELECT * FROM ChecklistItems WHERE cardId = ? ORDER BY position, id;
+
+-- name: GetChecklistItem :one
+SELECT * FROM ChecklistItems WHERE id = ? AND cardId = ? LIMIT 1;
+
+-- name: CreateChecklistItem :one
+INSERT INTO ChecklistItems (cardId, title, position)
+VALUES (?, ?, ?)
+RETURNING *;
+
+-- name: UpdateChecklistItemPosition :exec
+UPDATE ChecklistItems SET position = ? WHERE id = ?;
+
+-- name: SetChecklistItemDone :exec
+UPDATE ChecklistItems SET isDone = ?, completedAt = ? WHERE id = ?;
+
+-- name: DeleteChecklistItem :exec
+DELETE FROM ChecklistItems WHERE id = ? AND cardId = ?;
@@ -0,0 +1,24 @@
+-- name: GetRunningFocusSession :one
+SELECT * FROM FocusSessions WHERE endedAt IS NULL ORDER BY id DESC LIMIT 1;
+
+-- name: CreateFocusSession :one
+INSERT INTO FocusSessions (cardId, projectId, phase, cycle, plannedSeconds, startedAt)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING id;
+
+-- name: UpdateFocusSessionPlan :exec
+UPDATE FocusSessions SET plannedSeconds = ? WHERE id = ?;
+
+-- name: IncrementFocusSessionInterruptions :exec
+UPDATE FocusSessions SET interruptions = interruptions + 1 WHERE id = ?;
+
+-- name: EndFocusSession :exec
+UPDATE FocusSessions SET endedAt = ?, outcome = ? WHERE id = ? AND endedAt IS NULL;
+
+-- name: DeleteFocusSessionsForCard :exec
+DELETE FROM FocusSessions WHERE cardId = ?;
+
+-- name: ListFocusSessionsInRange :many
+SELECT * FROM FocusSessions
+WHERE startedAt >= sqlc.arg(range_start) AND startedAt < sqlc.arg(range_end)
+ORDER BY startedAt;
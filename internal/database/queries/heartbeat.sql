@@ -0,0 +1,6 @@
+-- name: GetLastHeartbeat :one
+SELECT * FROM AppHeartbeat WHERE id = 1;
+
+-- name: UpsertHeartbeat :exec
+INSERT INTO AppHeartbeat (id, lastSeenAt, stoppedCleanly) VALUES (1, ?, ?)
+ON CONFLICT(id) DO UPDATE SET lastSeenAt = EXCLUDED.lastSeenAt, stoppedCleanly = EXCLUDED.stoppedCleanly;
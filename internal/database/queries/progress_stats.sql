@@ -1,43 +1,44 @@
 -- name: AggregateWeekHours :one
-SELECT CAST(IFNULL(SUM(te.duration), 0) AS FLOAT) AS totalTrackedMinsCurrentWeek
+SELECT CAST(IFNULL(SUM(te.duration), 0) AS FLOAT) AS totalTrackedSecondsCurrentWeek
 FROM TimeEntries te
 JOIN Cards c ON te.cardId = c.id
 JOIN Projects p ON c.projectId = p.id
-WHERE p.id = ?
+WHERE p.id = ? AND c.deletedAt IS NULL
 AND strftime('%Y-%W', te.startTime) = strftime('%Y-%W', 'now');
 
 -- name: AggregatePreviousWeekHours :one
-SELECT CAST(IFNULL(SUM(te.duration), 0) AS FLOAT) AS totalTrackedMinsPreviousWeek
+SELECT CAST(IFNULL(SUM(te.duration), 0) AS FLOAT) AS totalTrackedSecondsPreviousWeek
 FROM TimeEntries te
 JOIN Cards c ON te.cardId = c.id
 JOIN Projects p ON c.projectId = p.id
-WHERE p.id = ?
+WHERE p.id = ? AND c.deletedAt IS NULL
 AND strftime('%Y-%W', te.startTime) = strftime('%Y-%W', 'now', '-7 days');
 
 -- name: AggregateMonthHours :one
-SELECT CAST(IFNULL(SUM(te.duration), 0) AS FLOAT) AS totalTrackedMinsCurrentMonth
+SELECT CAST(IFNULL(SUM(te.duration), 0) AS FLOAT) AS totalTrackedSecondsCurrentMonth
 FROM TimeEntries te
 JOIN Cards c ON te.cardId = c.id
 JOIN Projects p ON c.projectId = p.id
-WHERE p.id = ?
+WHERE p.id = ? AND c.deletedAt IS NULL
 AND strftime('%Y-%m', te.startTime) = strftime('%Y-%m', 'now');
 
 -- name: AggregatePreviousMonthHours :one
-SELECT CAST(IFNULL(SUM(te.duration), 0) AS FLOAT) AS totalTrackedMinsPreviousMonth
+SELECT CAST(IFNULL(SUM(te.duration), 0) AS FLOAT) AS totalTrackedSecondsPreviousMonth
 FROM TimeEntries te
 JOIN Cards c ON te.cardId = c.id
 JOIN Projects p ON c.projectId = p.id
-WHERE p.id = ?
+WHERE p.id = ? AND c.deletedAt IS NULL
 AND strftime('%Y-%m', te.startTime) = strftime('%Y-%m', 'now', 'start of month', '-1 month');
 
 -- name: GetDailyTotalMinutes :many
 SELECT
     DATE(startTime) AS date,
-    SUM(duration) AS total_minutes
+    SUM(duration) / 60.0 AS total_minutes
 FROM
     TimeEntries
 WHERE
     startTime >= DATE('now', '-1 year')
+    AND cardId IN (SELECT id FROM Cards WHERE deletedAt IS NULL)
 GROUP BY
     DATE(startTime)
 ORDER BY
@@ -46,19 +47,23 @@ ORDER BY
 -- name: GetWeeklyProgress :one
 SELECT COUNT(DISTINCT DATE(startTime)) AS progress_days
 FROM TimeEntries
-WHERE strftime('%Y-%W', startTime) = strftime('%Y-%W', 'now');
+WHERE strftime('%Y-%W', startTime) = strftime('%Y-%W', 'now')
+AND cardId IN (SELECT id FROM Cards WHERE deletedAt IS NULL);
 
 -- name: GetPreviousWeeklyProgress :one
 SELECT COUNT(DISTINCT DATE(startTime)) AS progress_days
 FROM TimeEntries
-WHERE strftime('%Y-%W', startTime) = strftime('%Y-%W', 'now', '-7 days');
+WHERE strftime('%Y-%W', startTime) = strftime('%Y-%W', 'now', '-7 days')
+AND cardId IN (SELECT id FROM Cards WHERE deletedAt IS NULL);
 
 -- name: GetMonthlyProgress :one
 SELECT COUNT(DISTINCT DATE(startTime)) AS progress_days
 FROM TimeEntries
-WHERE strftime('%Y-%m', startTime) = strftime('%Y-%m', 'now');
+WHERE strftime('%Y-%m', startTime) = strftime('%Y-%m', 'now')
+AND cardId IN (SELECT id FROM Cards WHERE deletedAt IS NULL);
 
 -- name: GetPreviousMonthlyProgress :one
 SELECT COUNT(DISTINCT DATE(startTime)) AS progress_days
 FROM TimeEntries
-WHERE strftime('%Y-%m', startTime) = strftime('%Y-%m', 'now', 'start of month', '-1 month');
+WHERE strftime('%Y-%m', startTime) = strftime('%Y-%m', 'now', 'start of month', '-1 month')
+AND cardId IN (SELECT id FROM Cards WHERE deletedAt IS NULL);
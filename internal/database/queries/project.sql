@@ -0,0 +1,23 @@
+-- name: GetProject :one
+SELECT * FROM Projects WHERE id = ? LIMIT 1;
+
+-- name: ListProjects :many
+SELECT * FROM Projects WHERE archivedAt IS NULL ORDER BY id;
+
+-- name: ListArchivedProjects :many
+SELECT * FROM Projects WHERE archivedAt IS NOT NULL ORDER BY archivedAt DESC;
+
+-- name: CreateProject :one
+INSERT INTO Projects (name) VALUES (?) RETURNING *;
+
+-- name: RenameProject :exec
+UPDATE Projects SET name = ? WHERE id = ?;
+
+-- name: SetProjectArchivedAt :exec
+UPDATE Projects SET archivedAt = ? WHERE id = ?;
+
+-- name: DeleteProject :exec
+DELETE FROM Projects WHERE id = ?;
+
+-- name: ReassignProjectCards :exec
+UPDATE Cards SET projectId = sqlc.arg(target_project_id) WHERE projectId = sqlc.arg(source_project_id);
@@ -0,0 +1,12 @@
+-- name: SearchCards :many
+SELECT c.id AS card_id, c.projectId, p.name AS project_name, c.title, c.status,
+    CAST(snippet(CardSearch, -1, char(2), char(3), '…', 12) AS TEXT) AS snippet,
+    CAST(bm25(CardSearch, 10.0, 2.0, 1.0) AS REAL) AS score
+FROM CardSearch
+JOIN Cards c ON c.id = CardSearch.rowid
+JOIN Projects p ON p.id = c.projectId
+WHERE CardSearch MATCH sqlc.arg(query) AND c.deletedAt IS NULL
+AND (CAST(sqlc.arg(all_projects) AS BOOLEAN) OR c.projectId = sqlc.arg(project_id))
+AND (CAST(sqlc.arg(all_statuses) AS BOOLEAN) OR c.status = sqlc.arg(status))
+ORDER BY score, c.id
+LIMIT sqlc.arg(max_results);
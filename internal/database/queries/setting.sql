@@ -0,0 +1,12 @@
+-- name: ListSettings :many
+SELECT * FROM Settings;
+
+-- name: GetSettingValue :one
+SELECT value FROM Settings WHERE key = ? LIMIT 1;
+
+-- name: UpsertSetting :exec
+INSERT INTO Settings (key, value) VALUES (?, ?)
+ON CONFLICT(key) DO UPDATE SET value = EXCLUDED.value, updatedAt = CURRENT_TIMESTAMP;
+
+-- name: DeleteSetting :exec
+DELETE FROM Settings WHERE key = ?;
@@ -0,0 +1,20 @@
+-- name: ListActivityDays :many
+SELECT CAST(DATE(activityAt, CAST(sqlc.arg(day_offset) AS TEXT)) AS TEXT) AS day FROM (
+    SELECT startTime AS activityAt FROM TimeEntries
+    WHERE duration > 0 AND cardId IN (SELECT id FROM Cards WHERE deletedAt IS NULL)
+    UNION ALL
+    SELECT completionTime AS activityAt FROM TaskCompletions
+    WHERE cardId IN (SELECT id FROM Cards WHERE deletedAt IS NULL)
+)
+GROUP BY day
+ORDER BY day;
+
+-- name: ListRestDays :many
+SELECT * FROM StreakRestDays ORDER BY day;
+
+-- name: UpsertRestDay :exec
+INSERT INTO StreakRestDays (day, note) VALUES (?, ?)
+ON CONFLICT(day) DO UPDATE SET note = EXCLUDED.note;
+
+-- name: DeleteRestDay :exec
+DELETE FROM StreakRestDays WHERE day = ?;
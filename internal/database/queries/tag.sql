@@ -0,0 +1,41 @@
+-- name: ListTags :many
+SELECT * FROM Tags ORDER BY name;
+
+-- name: GetTag :one
+SELECT * FROM Tags WHERE id = ? LIMIT 1;
+
+-- name: GetTagByName :one
+SELECT * FROM Tags WHERE name = ? LIMIT 1;
+
+-- name: CreateTag :one
+INSERT INTO Tags (name, color) VALUES (?, ?) RETURNING *;
+
+-- name: UpdateTag :exec
+UPDATE Tags SET name = ?, color = ? WHERE id = ?;
+
+-- name: DeleteTag :exec
+DELETE FROM Tags WHERE id = ?;
+
+-- name: DeleteCardTagsForTag :exec
+DELETE FROM CardTags WHERE tagId = ?;
+
+-- name: AddCardTag :exec
+INSERT OR IGNORE INTO CardTags (cardId, tagId) VALUES (?, ?);
+
+-- name: RemoveCardTag :exec
+DELETE FROM CardTags WHERE cardId = ? AND tagId = ?;
+
+-- name: ListTagsForCard :many
+SELECT t.* FROM Tags t JOIN CardTags ct ON t.id = ct.tagId WHERE ct.cardId = ? ORDER BY t.name;
+
+-- name: ListCardTagsForProject :many
+SELECT ct.* FROM CardTags ct JOIN Cards c ON c.id = ct.cardId WHERE c.projectId = ? AND c.deletedAt IS NULL;
+
+-- name: CountTagsForProject :many
+SELECT t.id, t.name, t.color, COUNT(ct.cardId) AS card_count
+FROM Tags t
+JOIN CardTags ct ON ct.tagId = t.id
+JOIN Cards c ON c.id = ct.cardId
+WHERE c.projectId = ? AND c.status = ? AND c.deletedAt IS NULL
+GROUP BY t.id, t.name, t.color
+ORDER BY t.name;
@@ -5,19 +5,29 @@ INSERT INTO TaskCompletions (
     baseExp,
     timeBonusExp,
     streakBonusExp,
-    totalExp
+    totalExp,
+    checklistItemId
 ) VALUES (
-    ?, ?, ?, ?, ?, ?
+    ?, ?, ?, ?, ?, ?, ?
 ) RETURNING *;
 
 -- name: GetTaskCompletion :one
 SELECT * FROM TaskCompletions
-WHERE cardId = ? AND userId = ?;
+WHERE cardId = ? AND userId = ? AND checklistItemId IS NULL;
+
+-- name: GetChecklistItemCompletion :one
+SELECT * FROM TaskCompletions
+WHERE checklistItemId = ? AND userId = ?;
 
 -- name: ListTaskCompletionsByUser :many
 SELECT * FROM TaskCompletions
-WHERE userId = ?
+WHERE userId = ? AND cardId IN (SELECT id FROM Cards WHERE deletedAt IS NULL)
 ORDER BY completionTime DESC;
 
+-- name: SumCardExp :one
+SELECT CAST(IFNULL(SUM(totalExp), 0) AS INTEGER) as total_exp FROM TaskCompletions
+WHERE cardId = ? AND userId = ?;
+
 -- name: TotalUserExp :one
-SELECT CAST(IFNULL(SUM(totalExp), 0) AS FLOAT) as total_exp FROM TaskCompletions WHERE userId = ?;
+SELECT CAST(IFNULL(SUM(totalExp), 0) AS FLOAT) as total_exp FROM TaskCompletions
+WHERE userId = ? AND cardId IN (SELECT id FROM Cards WHERE deletedAt IS NULL);
@@ -0,0 +1,31 @@
+-- name: GetTimeEntry :one
+SELECT * FROM TimeEntries WHERE id = ? AND cardId = ? LIMIT 1;
+
+-- name: ListTimeEntriesByCard :many
+SELECT * FROM TimeEntries WHERE cardId = ? ORDER BY startTime;
+
+-- name: ListTimeEntriesInRange :many
+SELECT te.*, c.title AS card_title, c.projectId
+FROM TimeEntries te
+JOIN Cards c ON te.cardId = c.id
+WHERE te.startTime >= sqlc.arg(range_start) AND te.startTime < sqlc.arg(range_end)
+AND c.deletedAt IS NULL
+ORDER BY te.startTime;
+
+-- name: ListOverlappingTimeEntries :many
+SELECT * FROM TimeEntries
+WHERE cardId IN (SELECT id FROM Cards WHERE deletedAt IS NULL)
+AND id != sqlc.arg(exclude_id)
+AND startTime < sqlc.arg(range_end)
+AND (endTime > sqlc.arg(range_start) OR startTime = endTime);
+
+-- name: InsertTimeEntry :one
+INSERT INTO TimeEntries (cardId, startTime, endTime, duration)
+VALUES (?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdateTimeEntry :exec
+UPDATE TimeEntries SET startTime = ?, endTime = ?, duration = ? WHERE id = ?;
+
+-- name: DeleteTimeEntry :exec
+DELETE FROM TimeEntries WHERE id = ?;
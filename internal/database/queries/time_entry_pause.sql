@@ -0,0 +1,16 @@
+-- name: GetOpenTimeEntryPause :one
+SELECT * FROM TimeEntryPauses WHERE timeEntryId = ? AND resumedAt IS NULL LIMIT 1;
+
+-- name: ListTimeEntryPauses :many
+SELECT * FROM TimeEntryPauses WHERE timeEntryId = ? ORDER BY pausedAt;
+
+-- name: CreateTimeEntryPause :exec
+INSERT INTO TimeEntryPauses (timeEntryId, pausedAt, resumedAt)
+VALUES (?, ?, ?);
+
+-- name: ResumeTimeEntryPause :exec
+UPDATE TimeEntryPauses SET resumedAt = ? WHERE id = ?;
+
+-- name: MoveTimeEntryPauses :exec
+UPDATE TimeEntryPauses SET timeEntryId = sqlc.arg(new_time_entry_id)
+WHERE timeEntryId = sqlc.arg(time_entry_id) AND pausedAt >= sqlc.arg(paused_from);
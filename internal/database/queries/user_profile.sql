@@ -0,0 +1,16 @@
+-- name: GetUserProgression :one
+SELECT id, progressionPoints, archerLevel, archerExperience FROM UserProfile WHERE id = ? LIMIT 1;
+
+-- name: UpdateUserProgression :exec
+UPDATE UserProfile
+SET progressionPoints = ?, archerLevel = ?, archerExperience = ?, updatedAt = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: CreateLevelHistory :exec
+INSERT INTO LevelHistory (userId, level, totalExp) VALUES (?, ?, ?);
+
+-- name: ListLevelHistory :many
+SELECT * FROM LevelHistory WHERE userId = ? ORDER BY reachedAt, level;
+
+-- name: DeleteLevelHistoryAbove :exec
+DELETE FROM LevelHistory WHERE userId = ? AND level > ?;
@@ -2,9 +2,15 @@
 SELECT * FROM UserSkillProgress WHERE user_id = ? AND skill_id = ? LIMIT 1;
 
 -- name: UpsertUserSkillProgress :one
-INSERT INTO UserSkillProgress (user_id, skill_id, total_minutes_tracked)
-VALUES (?, ?, ?)
+INSERT INTO UserSkillProgress (user_id, skill_id, total_seconds_tracked, total_minutes_tracked)
+VALUES (?, ?, ?, ?)
 ON CONFLICT(user_id, skill_id) DO UPDATE SET
-    total_minutes_tracked = total_minutes_tracked + EXCLUDED.total_minutes_tracked,
+    total_seconds_tracked = total_seconds_tracked + EXCLUDED.total_seconds_tracked,
+    total_minutes_tracked = (total_seconds_tracked + EXCLUDED.total_seconds_tracked) / 60,
     last_updated = CURRENT_TIMESTAMP
 RETURNING *;
+
+-- name: SetUserSkillProgressTime :exec
+UPDATE UserSkillProgress
+SET total_seconds_tracked = ?, total_minutes_tracked = ?, last_updated = CURRENT_TIMESTAMP
+WHERE id = ?;
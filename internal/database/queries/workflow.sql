@@ -0,0 +1,48 @@
+-- name: ListWorkflowStatuses :many
+SELECT * FROM WorkflowStatuses WHERE projectId = ? ORDER BY position, id;
+
+-- name: GetWorkflowStatus :one
+SELECT * FROM WorkflowStatuses WHERE id = ? AND projectId = ? LIMIT 1;
+
+-- name: GetWorkflowStatusByName :one
+SELECT * FROM WorkflowStatuses WHERE projectId = ? AND name = ? LIMIT 1;
+
+-- name: GetMaxWorkflowStatusPosition :one
+SELECT CAST(IFNULL(MAX(position), -1) AS INTEGER) AS max_position FROM WorkflowStatuses WHERE projectId = ?;
+
+-- name: CreateWorkflowStatus :one
+INSERT INTO WorkflowStatuses (projectId, name, position, isDone) VALUES (?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdateWorkflowStatus :exec
+UPDATE WorkflowStatuses SET name = ?, isDone = ? WHERE id = ?;
+
+-- name: UpdateWorkflowStatusPosition :exec
+UPDATE WorkflowStatuses SET position = ? WHERE id = ?;
+
+-- name: DeleteWorkflowStatus :exec
+DELETE FROM WorkflowStatuses WHERE id = ?;
+
+-- name: DeleteWorkflowStatusesForProject :exec
+DELETE FROM WorkflowStatuses WHERE projectId = ?;
+
+-- name: CountCardsInWorkflowStatus :one
+SELECT COUNT(*) FROM Cards WHERE workflowStatusId = ?;
+
+-- name: MoveCardsToWorkflowStatus :exec
+UPDATE Cards SET workflowStatusId = sqlc.arg(target_status_id) WHERE workflowStatusId = sqlc.arg(source_status_id);
+
+-- name: ListWorkflowTransitions :many
+SELECT t.* FROM WorkflowTransitions t
+JOIN WorkflowStatuses ws ON ws.id = t.fromStatusId
+WHERE ws.projectId = ?
+ORDER BY t.fromStatusId, t.toStatusId;
+
+-- name: AddWorkflowTransition :exec
+INSERT OR IGNORE INTO WorkflowTransitions (fromStatusId, toStatusId) VALUES (?, ?);
+
+-- name: DeleteWorkflowTransitionsForStatus :exec
+DELETE FROM WorkflowTransitions WHERE sqlc.arg(status_id) IN (fromStatusId, toStatusId);
+
+-- name: DeleteWorkflowTransitionsForProject :exec
+DELETE FROM WorkflowTransitions WHERE fromStatusId IN (SELECT id FROM WorkflowStatuses WHERE projectId = ?);
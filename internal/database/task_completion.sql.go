@@ -7,6 +7,7 @@ package database
 
 import (
 	"context"
+	"database/sql"
 )
 
 const createTaskCompletion = `-- name: CreateTaskCompletion :one
@@ -16,19 +17,21 @@ INSERT INTO TaskCompletions (
     baseExp,
     timeBonusExp,
     streakBonusExp,
-    totalExp
+    totalExp,
+    checklistItemId
 ) VALUES (
-    ?, ?, ?, ?, ?, ?
-) RETURNING id, cardid, userid, completiontime, baseexp, timebonusexp, streakbonusexp, totalexp
+    ?, ?, ?, ?, ?, ?, ?
+) RETURNING id, cardid, userid, completiontime, baseexp, timebonusexp, streakbonusexp, totalexp, checklistItemId
 `
 
 type CreateTaskCompletionParams struct {
-	Cardid         int64 `json:"cardid"`
-	Userid         int64 `json:"userid"`
-	Baseexp        int64 `json:"baseexp"`
-	Timebonusexp   int64 `json:"timebonusexp"`
-	Streakbonusexp int64 `json:"streakbonusexp"`
-	Totalexp       int64 `json:"totalexp"`
+	Cardid          int64         `json:"cardid"`
+	Userid          int64         `json:"userid"`
+	Baseexp         int64         `json:"baseexp"`
+	Timebonusexp    int64         `json:"timebonusexp"`
+	Streakbonusexp  int64         `json:"streakbonusexp"`
+	Totalexp        int64         `json:"totalexp"`
+	ChecklistItemId sql.NullInt64 `json:"checklistItemId"`
 }
 
 func (q *Queries) CreateTaskCompletion(ctx context.Context, arg CreateTaskCompletionParams) (TaskCompletion, error) {
@@ -39,6 +42,7 @@ func (q *Queries) CreateTaskCompletion(ctx context.Context, arg CreateTaskComple
 		arg.Timebonusexp,
 		arg.Streakbonusexp,
 		arg.Totalexp,
+		arg.ChecklistItemId,
 	)
 	var i TaskCompletion
 	err := row.Scan(
@@ -50,13 +54,41 @@ func (q *Queries) CreateTaskCompletion(ctx context.Context, arg CreateTaskComple
 		&i.Timebonusexp,
 		&i.Streakbonusexp,
 		&i.Totalexp,
+		&i.ChecklistItemId,
+	)
+	return i, err
+}
+
+const getChecklistItemCompletion = `-- name: GetChecklistItemCompletion :one
+SELECT id, cardid, userid, completiontime, baseexp, timebonusexp, streakbonusexp, totalexp, checklistItemId FROM TaskCompletions
+WHERE checklistItemId = ? AND userId = ?
+`
+
+type GetChecklistItemCompletionParams struct {
+	ChecklistItemId sql.NullInt64 `json:"checklistItemId"`
+	Userid          int64         `json:"userid"`
+}
+
+func (q *Queries) GetChecklistItemCompletion(ctx context.Context, arg GetChecklistItemCompletionParams) (TaskCompletion, error) {
+	row := q.db.QueryRowContext(ctx, getChecklistItemCompletion, arg.ChecklistItemId, arg.Userid)
+	var i TaskCompletion
+	err := row.Scan(
+		&i.ID,
+		&i.Cardid,
+		&i.Userid,
+		&i.Completiontime,
+		&i.Baseexp,
+		&i.Timebonusexp,
+		&i.Streakbonusexp,
+		&i.Totalexp,
+		&i.ChecklistItemId,
 	)
 	return i, err
 }
 
 const getTaskCompletion = `-- name: GetTaskCompletion :one
-SELECT id, cardid, userid, completiontime, baseexp, timebonusexp, streakbonusexp, totalexp FROM TaskCompletions
-WHERE cardId = ? AND userId = ?
+SELECT id, cardid, userid, completiontime, baseexp, timebonusexp, streakbonusexp, totalexp, checklistItemId FROM TaskCompletions
+WHERE cardId = ? AND userId = ? AND checklistItemId IS NULL
 `
 
 type GetTaskCompletionParams struct {
@@ -76,13 +108,14 @@ func (q *Queries) GetTaskCompletion(ctx context.Context, arg GetTaskCompletionPa
 		&i.Timebonusexp,
 		&i.Streakbonusexp,
 		&i.Totalexp,
+		&i.ChecklistItemId,
 	)
 	return i, err
 }
 
 const listTaskCompletionsByUser = `-- name: ListTaskCompletionsByUser :many
-SELECT id, cardid, userid, completiontime, baseexp, timebonusexp, streakbonusexp, totalexp FROM TaskCompletions
-WHERE userId = ?
+SELECT id, cardid, userid, completiontime, baseexp, timebonusexp, streakbonusexp, totalexp, checklistItemId FROM TaskCompletions
+WHERE userId = ? AND cardId IN (SELECT id FROM Cards WHERE deletedAt IS NULL)
 ORDER BY completionTime DESC
 `
 
@@ -104,6 +137,7 @@ func (q *Queries) ListTaskCompletionsByUser(ctx context.Context, userid int64) (
 			&i.Timebonusexp,
 			&i.Streakbonusexp,
 			&i.Totalexp,
+			&i.ChecklistItemId,
 		); err != nil {
 			return nil, err
 		}
@@ -118,8 +152,26 @@ func (q *Queries) ListTaskCompletionsByUser(ctx context.Context, userid int64) (
 	return items, nil
 }
 
+const sumCardExp = `-- name: SumCardExp :one
+SELECT CAST(IFNULL(SUM(totalExp), 0) AS INTEGER) as total_exp FROM TaskCompletions
+WHERE cardId = ? AND userId = ?
+`
+
+type SumCardExpParams struct {
+	Cardid int64 `json:"cardid"`
+	Userid int64 `json:"userid"`
+}
+
+func (q *Queries) SumCardExp(ctx context.Context, arg SumCardExpParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, sumCardExp, arg.Cardid, arg.Userid)
+	var total_exp int64
+	err := row.Scan(&total_exp)
+	return total_exp, err
+}
+
 const totalUserExp = `-- name: TotalUserExp :one
-SELECT CAST(IFNULL(SUM(totalExp), 0) AS FLOAT) as total_exp FROM TaskCompletions WHERE userId = ?
+SELECT CAST(IFNULL(SUM(totalExp), 0) AS FLOAT) as total_exp FROM TaskCompletions
+WHERE userId = ? AND cardId IN (SELECT id FROM Cards WHERE deletedAt IS NULL)
 `
 
 func (q *Queries) TotalUserExp(ctx context.Context, userid int64) (float64, error) {
@@ -11,7 +11,7 @@ import (
 )
 
 const getUserSkillProgress = `-- name: GetUserSkillProgress :one
-SELECT id, user_id, skill_id, total_minutes_tracked, last_updated FROM UserSkillProgress WHERE user_id = ? AND skill_id = ? LIMIT 1
+SELECT id, user_id, skill_id, total_minutes_tracked, last_updated, total_seconds_tracked FROM UserSkillProgress WHERE user_id = ? AND skill_id = ? LIMIT 1
 `
 
 type GetUserSkillProgressParams struct {
@@ -28,27 +28,52 @@ func (q *Queries) GetUserSkillProgress(ctx context.Context, arg GetUserSkillProg
 		&i.SkillID,
 		&i.TotalMinutesTracked,
 		&i.LastUpdated,
+		&i.TotalSecondsTracked,
 	)
 	return i, err
 }
 
+const setUserSkillProgressTime = `-- name: SetUserSkillProgressTime :exec
+UPDATE UserSkillProgress
+SET total_seconds_tracked = ?, total_minutes_tracked = ?, last_updated = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+type SetUserSkillProgressTimeParams struct {
+	TotalSecondsTracked int64         `json:"total_seconds_tracked"`
+	TotalMinutesTracked sql.NullInt64 `json:"total_minutes_tracked"`
+	ID                  int64         `json:"id"`
+}
+
+func (q *Queries) SetUserSkillProgressTime(ctx context.Context, arg SetUserSkillProgressTimeParams) error {
+	_, err := q.db.ExecContext(ctx, setUserSkillProgressTime, arg.TotalSecondsTracked, arg.TotalMinutesTracked, arg.ID)
+	return err
+}
+
 const upsertUserSkillProgress = `-- name: UpsertUserSkillProgress :one
-INSERT INTO UserSkillProgress (user_id, skill_id, total_minutes_tracked)
-VALUES (?, ?, ?)
+INSERT INTO UserSkillProgress (user_id, skill_id, total_seconds_tracked, total_minutes_tracked)
+VALUES (?, ?, ?, ?)
 ON CONFLICT(user_id, skill_id) DO UPDATE SET
-    total_minutes_tracked = total_minutes_tracked + EXCLUDED.total_minutes_tracked,
+    total_seconds_tracked = total_seconds_tracked + EXCLUDED.total_seconds_tracked,
+    total_minutes_tracked = (total_seconds_tracked + EXCLUDED.total_seconds_tracked) / 60,
     last_updated = CURRENT_TIMESTAMP
-RETURNING id, user_id, skill_id, total_minutes_tracked, last_updated
+RETURNING id, user_id, skill_id, total_minutes_tracked, last_updated, total_seconds_tracked
 `
 
 type UpsertUserSkillProgressParams struct {
 	UserID              int64         `json:"user_id"`
 	SkillID             int64         `json:"skill_id"`
+	TotalSecondsTracked int64         `json:"total_seconds_tracked"`
 	TotalMinutesTracked sql.NullInt64 `json:"total_minutes_tracked"`
 }
 
 func (q *Queries) UpsertUserSkillProgress(ctx context.Context, arg UpsertUserSkillProgressParams) (UserSkillProgress, error) {
-	row := q.db.QueryRowContext(ctx, upsertUserSkillProgress, arg.UserID, arg.SkillID, arg.TotalMinutesTracked)
+	row := q.db.QueryRowContext(ctx, upsertUserSkillProgress,
+		arg.UserID,
+		arg.SkillID,
+		arg.TotalSecondsTracked,
+		arg.TotalMinutesTracked,
+	)
 	var i UserSkillProgress
 	err := row.Scan(
 		&i.ID,
@@ -56,6 +81,7 @@ func (q *Queries) UpsertUserSkillProgress(ctx context.Context, arg UpsertUserSki
 		&i.SkillID,
 		&i.TotalMinutesTracked,
 		&i.LastUpdated,
+		&i.TotalSecondsTracked,
 	)
 	return i, err
 }
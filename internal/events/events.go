@@ -7,6 +7,37 @@ const (
 	CardStoppedTopic = "card:stopped"
 	// CardStartedTopic is the topic for when a card is started.
 	CardStartedTopic = "card:started"
+	// CardPausedTopic is the topic for when tracking of a card is paused without ending its session.
+	CardPausedTopic = "card:paused"
+	// CardResumedTopic is the topic for when a paused card is resumed.
+	CardResumedTopic = "card:resumed"
+	// TimeEntryChangedTopic is the topic for when time entries are added, edited or removed manually.
+	TimeEntryChangedTopic = "timeentry:changed"
+	// LevelUpTopic is the topic for when the user reaches a new level.
+	LevelUpTopic = "progress:levelup"
+	// LevelChangedTopic is the topic for when trashing or restoring a card changes the user's EXP,
+	// or a level curve change moves the user to another level.
+	LevelChangedTopic = "progress:levelchanged"
+	// SettingChangedTopic is the topic for when a setting is changed or reset.
+	SettingChangedTopic = "settings:changed"
+	// FocusPhaseChangedTopic is the topic for when the focus timer moves between work and break phases.
+	FocusPhaseChangedTopic = "focus:phase"
+	// StaleTimeEntriesTopic is the topic for when time entries left running by a crash are found on startup.
+	StaleTimeEntriesTopic = "timeentry:stale"
+	// IdleDetectedTopic is the topic for when the computer slept or the app stalled while a card was tracked.
+	IdleDetectedTopic = "timeentry:idle"
+	// CardDueSoonTopic is the topic for when an open card is about to reach its due date.
+	CardDueSoonTopic = "card:duesoon"
+	// CardsBulkChangedTopic is the topic for when a bulk operation changed several cards at once.
+	CardsBulkChangedTopic = "card:bulkchanged"
+)
+
+// Phases of the focus timer.
+const (
+	FocusPhaseIdle       = "idle"
+	FocusPhaseWork       = "work"
+	FocusPhaseShortBreak = "short_break"
+	FocusPhaseLongBreak  = "long_break"
 )
 
 // CardStoppedEvent is the data for the event when a card is stopped.
@@ -25,3 +56,128 @@ type CardStartedEvent struct {
 	UserID    int64
 	StartedAt time.Time
 }
+
+// CardPausedEvent is the data for the event when tracking of a card is paused.
+type CardPausedEvent struct {
+	CardID    int64
+	ProjectID int64
+	UserID    int64
+	PausedAt  time.Time
+}
+
+// CardResumedEvent is the data for the event when a paused card is resumed. PausedFor is the
+// length of the pause that just ended.
+type CardResumedEvent struct {
+	CardID    int64
+	ProjectID int64
+	UserID    int64
+	ResumedAt time.Time
+	PausedFor time.Duration
+}
+
+// TimeEntryChangedEvent is the data for the event when a card's tracked time is corrected manually.
+// Delta is the change in tracked time and is negative when time was removed.
+type TimeEntryChangedEvent struct {
+	CardID    int64
+	ProjectID int64
+	UserID    int64
+	Delta     time.Duration
+	ChangedAt time.Time
+}
+
+// LevelUpEvent is the data for the event when a task completion raises the user's level.
+type LevelUpEvent struct {
+	UserID        int64
+	PreviousLevel int64
+	NewLevel      int64
+	TotalExp      int64
+	ReachedAt     time.Time
+}
+
+// LevelChangedEvent is the data for the event when the EXP of a trashed or restored card is
+// taken off or given back, or the level is recomputed for a new level curve. NewLevel is lower
+// than PreviousLevel when levels were lost.
+type LevelChangedEvent struct {
+	UserID        int64
+	PreviousLevel int64
+	NewLevel      int64
+	TotalExp      int64
+	ChangedAt     time.Time
+}
+
+// SettingChangedEvent is the data for the event when a setting's effective value changes.
+// The values are in their canonical string form.
+type SettingChangedEvent struct {
+	Key       string
+	OldValue  string
+	NewValue  string
+	ChangedAt time.Time
+}
+
+// FocusPhaseChangedEvent is the data for the event when the focus timer enters a new phase.
+type FocusPhaseChangedEvent struct {
+	CardID        int64
+	ProjectID     int64
+	PreviousPhase string
+	Phase         string
+	// Cycle is the number of work sessions completed since the last long break.
+	Cycle     int
+	StartedAt time.Time
+	// EndsAt is zero for phases without a planned end, and while the phase is paused.
+	EndsAt time.Time
+	// Paused is set while the focused card's tracking is paused within a work phase.
+	Paused bool
+}
+
+// StaleTimeEntry is a time entry that was still running when the app started after a crash.
+// ClosedAt is zero while the entry waits for the user to resolve it.
+type StaleTimeEntry struct {
+	CardID      int64
+	ProjectID   int64
+	TimeEntryID int64
+	CardTitle   string
+	StartedAt   time.Time
+	ClosedAt    time.Time
+	Resolution  string
+}
+
+// StaleTimeEntriesEvent is the data for the event when a startup reconciliation found running
+// time entries. LastSeenAt is the last heartbeat before the crash.
+type StaleTimeEntriesEvent struct {
+	LastSeenAt time.Time
+	DetectedAt time.Time
+	Entries    []StaleTimeEntry
+}
+
+// IdleDetectedEvent is the data for the event when a gap was found in the running time entry of
+// a tracked card. The gap is counted as tracked time until the user resolves it.
+type IdleDetectedEvent struct {
+	CardID      int64
+	ProjectID   int64
+	TimeEntryID int64
+	GapStart    time.Time
+	GapEnd      time.Time
+	DetectedAt  time.Time
+}
+
+// CardDueSoonEvent is the data for the event when a card's due date is within the reminder lead
+// time. It is published once per due date.
+type CardDueSoonEvent struct {
+	CardID     int64
+	ProjectID  int64
+	Title      string
+	DueAt      time.Time
+	RemindedAt time.Time
+}
+
+// CardsBulkChangedEvent is the data for the event when a bulk operation changed several cards in
+// one transaction. CardIDs lists the cards that were changed, and TimeDeltas the change in
+// tracked time attributed to each project, by project ID.
+type CardsBulkChangedEvent struct {
+	Operation  string
+	ProjectID  int64
+	UserID     int64
+	CardIDs    []int64
+	TimeDeltas map[int64]time.Duration
+	ChangedAt  time.Time
+}
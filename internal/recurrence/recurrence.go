@@ -0,0 +1,328 @@
+// Package recurrence parses and evaluates the subset of RFC 5545 recurrence rules (RRULE) used
+// by repeating cards: FREQ=DAILY, WEEKLY or MONTHLY with INTERVAL, BYDAY (weekly only),
+// BYMONTHDAY (monthly only), COUNT and UNTIL.
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+const (
+	untilDateLayout     = "20060102"
+	untilDateTimeLayout = "20060102T150405Z"
+
+	// maxSteps bounds the search for the next occurrence of a rule.
+	maxSteps = 100000
+)
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Rule is a parsed recurrence rule. The zero values of Count and Until mean the recurrence does
+// not end.
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	// ByDay lists the weekdays of a weekly rule, Monday first.
+	ByDay []time.Weekday
+	// ByMonthDay lists the days of a monthly rule. Negative days count back from the end of the
+	// month, so -1 is the last day.
+	ByMonthDay []int
+	Count      int
+	Until      time.Time
+	// untilDate is set when UNTIL was a date, which includes the whole day in the occurrence's
+	// location.
+	untilDate bool
+}
+
+// Occurrence is one instance of a recurrence. Index counts occurrences from 1.
+type Occurrence struct {
+	At    time.Time
+	Index int
+}
+
+// Parse reads a rule such as "FREQ=WEEKLY;BYDAY=MO,WE,FR". An "RRULE:" prefix is accepted and
+// names and values are case-insensitive.
+func Parse(s string) (Rule, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	s = strings.TrimPrefix(s, "RRULE:")
+	if s == "" {
+		return Rule{}, fmt.Errorf("%w: rule is empty", ErrInvalidRule)
+	}
+
+	rule := Rule{Interval: 1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return Rule{}, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+		if seen[name] {
+			return Rule{}, fmt.Errorf("%w: %s given more than once", ErrInvalidRule, name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			rule.Freq = Frequency(value)
+			if rule.Freq != Daily && rule.Freq != Weekly && rule.Freq != Monthly {
+				err = fmt.Errorf("unsupported frequency %s", value)
+			}
+		case "INTERVAL":
+			rule.Interval, err = parsePositive(value)
+		case "COUNT":
+			rule.Count, err = parsePositive(value)
+		case "UNTIL":
+			rule.Until, rule.untilDate, err = parseUntil(value)
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseByMonthDay(value)
+		default:
+			err = fmt.Errorf("unsupported part %s", name)
+		}
+		if err != nil {
+			return Rule{}, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+		}
+	}
+
+	switch {
+	case rule.Freq == "":
+		return Rule{}, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	case rule.Count > 0 && !rule.Until.IsZero():
+		return Rule{}, fmt.Errorf("%w: COUNT and UNTIL cannot be combined", ErrInvalidRule)
+	case len(rule.ByDay) > 0 && rule.Freq != Weekly:
+		return Rule{}, fmt.Errorf("%w: BYDAY is only supported with FREQ=WEEKLY", ErrInvalidRule)
+	case len(rule.ByMonthDay) > 0 && rule.Freq != Monthly:
+		return Rule{}, fmt.Errorf("%w: BYMONTHDAY is only supported with FREQ=MONTHLY", ErrInvalidRule)
+	}
+	return rule, nil
+}
+
+// String returns the rule in canonical form, which Parse reads back unchanged.
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = weekdayCode(day)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, day := range r.ByMonthDay {
+			days[i] = strconv.Itoa(day)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		if r.untilDate {
+			parts = append(parts, "UNTIL="+r.Until.Format(untilDateLayout))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilDateTimeLayout))
+		}
+	}
+	return strings.Join(parts, ";")
+}
+
+// NextAfter returns the first occurrence later than after that follows from, which must itself
+// be an occurrence of the rule. Occurrences are computed in the location of from.At, so they
+// keep their time of day across daylight saving changes. ok is false when COUNT or UNTIL ends
+// the recurrence first.
+func (r Rule) NextAfter(from Occurrence, after time.Time) (next Occurrence, ok bool) {
+	current := from
+	for range maxSteps {
+		at, ok := r.step(from.At, current.At)
+		if !ok {
+			return Occurrence{}, false
+		}
+		current = Occurrence{At: at, Index: current.Index + 1}
+
+		if r.Count > 0 && current.Index > r.Count {
+			return Occurrence{}, false
+		}
+		if r.pastUntil(current.At) {
+			return Occurrence{}, false
+		}
+		if current.At.After(after) {
+			return current, true
+		}
+	}
+	return Occurrence{}, false
+}
+
+// step returns the occurrence after current. anchor is the occurrence the search started from
+// and aligns the weeks or months skipped by INTERVAL.
+func (r Rule) step(anchor time.Time, current time.Time) (time.Time, bool) {
+	interval := max(r.Interval, 1)
+
+	switch r.Freq {
+	case Daily:
+		return current.AddDate(0, 0, interval), true
+	case Weekly:
+		if len(r.ByDay) == 0 {
+			return current.AddDate(0, 0, 7*interval), true
+		}
+		for day := current.AddDate(0, 0, 1); ; day = day.AddDate(0, 0, 1) {
+			if slices.Contains(r.ByDay, day.Weekday()) && weeksBetween(anchor, day)%interval == 0 {
+				return day, true
+			}
+		}
+	case Monthly:
+		days := r.ByMonthDay
+		if len(days) == 0 {
+			days = []int{anchor.Day()}
+		}
+		// A month without any of the days, e.g. the 31st in February, is skipped.
+		for months := 0; months <= 48*interval; months += interval {
+			first := time.Date(current.Year(), current.Month()+time.Month(months), 1, 0, 0, 0, 0, current.Location())
+			if at, ok := firstInMonth(first, days, current); ok {
+				return at, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// firstInMonth returns the earliest of days in the month starting at first that is later than
+// current, at current's time of day.
+func firstInMonth(first time.Time, days []int, current time.Time) (time.Time, bool) {
+	daysInMonth := first.AddDate(0, 1, -1).Day()
+
+	var candidates []time.Time
+	for _, day := range days {
+		if day < 0 {
+			day = daysInMonth + day + 1
+		}
+		if day < 1 || day > daysInMonth {
+			continue
+		}
+		at := time.Date(first.Year(), first.Month(), day,
+			current.Hour(), current.Minute(), current.Second(), current.Nanosecond(), current.Location())
+		if at.After(current) {
+			candidates = append(candidates, at)
+		}
+	}
+	if len(candidates) == 0 {
+		return time.Time{}, false
+	}
+	return slices.MinFunc(candidates, func(a, b time.Time) int { return a.Compare(b) }), true
+}
+
+func (r Rule) pastUntil(at time.Time) bool {
+	if r.Until.IsZero() {
+		return false
+	}
+	if r.untilDate {
+		return civilDate(at).After(civilDate(r.Until))
+	}
+	return at.After(r.Until)
+}
+
+// weeksBetween counts the weeks, starting on Monday, from the week of a to the week of b.
+func weeksBetween(a time.Time, b time.Time) int {
+	days := int(weekStart(b).Sub(weekStart(a)).Hours() / 24)
+	return days / 7
+}
+
+func weekStart(t time.Time) time.Time {
+	date := civilDate(t)
+	return date.AddDate(0, 0, -((int(t.Weekday()) + 6) % 7))
+}
+
+// civilDate returns the calendar date of t as midnight UTC, so dates can be compared and
+// subtracted without daylight saving shifts.
+func civilDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func parsePositive(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%q is not a positive number", value)
+	}
+	return n, nil
+}
+
+func parseUntil(value string) (time.Time, bool, error) {
+	if until, err := time.Parse(untilDateLayout, value); err == nil {
+		return until, true, nil
+	}
+	if until, err := time.Parse(untilDateTimeLayout, value); err == nil {
+		return until, false, nil
+	}
+	return time.Time{}, false, fmt.Errorf("UNTIL %q must be YYYYMMDD or YYYYMMDDTHHMMSSZ", value)
+}
+
+func parseByDay(value string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, code := range strings.Split(value, ",") {
+		day, ok := weekdayCodes[code]
+		if !ok {
+			return nil, fmt.Errorf("unsupported BYDAY value %q", code)
+		}
+		if !slices.Contains(days, day) {
+			days = append(days, day)
+		}
+	}
+	slices.SortFunc(days, func(a, b time.Weekday) int { return mondayFirst(a) - mondayFirst(b) })
+	return days, nil
+}
+
+func parseByMonthDay(value string) ([]int, error) {
+	var days []int
+	for _, part := range strings.Split(value, ",") {
+		day, err := strconv.Atoi(part)
+		if err != nil || day == 0 || day < -31 || day > 31 {
+			return nil, fmt.Errorf("BYMONTHDAY value %q must be 1 to 31 or -31 to -1", part)
+		}
+		if !slices.Contains(days, day) {
+			days = append(days, day)
+		}
+	}
+	return days, nil
+}
+
+func weekdayCode(day time.Weekday) string {
+	for code, d := range weekdayCodes {
+		if d == day {
+			return code
+		}
+	}
+	return ""
+}
+
+func mondayFirst(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
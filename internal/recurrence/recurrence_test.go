@@ -0,0 +1,173 @@
+package recurrence
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		want    string
+		wantErr bool
+	}{
+		{name: "daily", rule: "FREQ=DAILY", want: "FREQ=DAILY"},
+		{name: "prefix and lower case", rule: "rrule:freq=daily;interval=2", want: "FREQ=DAILY;INTERVAL=2"},
+		{name: "weekdays are sorted", rule: "FREQ=WEEKLY;BYDAY=FR,MO,WE,MO", want: "FREQ=WEEKLY;BYDAY=MO,WE,FR"},
+		{name: "monthly last day", rule: "FREQ=MONTHLY;BYMONTHDAY=1,-1;COUNT=6", want: "FREQ=MONTHLY;BYMONTHDAY=1,-1;COUNT=6"},
+		{name: "until date", rule: "FREQ=DAILY;UNTIL=20250131", want: "FREQ=DAILY;UNTIL=20250131"},
+		{name: "until time", rule: "FREQ=DAILY;UNTIL=20250131T170000Z", want: "FREQ=DAILY;UNTIL=20250131T170000Z"},
+		{name: "empty", rule: "", wantErr: true},
+		{name: "missing freq", rule: "INTERVAL=2", wantErr: true},
+		{name: "unsupported freq", rule: "FREQ=HOURLY", wantErr: true},
+		{name: "unsupported part", rule: "FREQ=DAILY;BYHOUR=9", wantErr: true},
+		{name: "zero interval", rule: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+		{name: "duplicate part", rule: "FREQ=DAILY;FREQ=WEEKLY", wantErr: true},
+		{name: "ordinal weekday", rule: "FREQ=WEEKLY;BYDAY=1MO", wantErr: true},
+		{name: "byday needs weekly", rule: "FREQ=MONTHLY;BYDAY=MO", wantErr: true},
+		{name: "bymonthday needs monthly", rule: "FREQ=WEEKLY;BYMONTHDAY=1", wantErr: true},
+		{name: "month day zero", rule: "FREQ=MONTHLY;BYMONTHDAY=0", wantErr: true},
+		{name: "count with until", rule: "FREQ=DAILY;COUNT=2;UNTIL=20250131", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRule) {
+					t.Fatalf("Parse(%q) error = %v, want ErrInvalidRule", tt.rule, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.rule, err)
+			}
+			if got := rule.String(); got != tt.want {
+				t.Errorf("Parse(%q).String() = %q, want %q", tt.rule, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextAfter(t *testing.T) {
+	at := func(value string) time.Time {
+		t.Helper()
+		parsed, err := time.Parse("2006-01-02 15:04", value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parsed
+	}
+
+	tests := []struct {
+		name      string
+		rule      string
+		from      string
+		index     int
+		after     string
+		want      string
+		wantIndex int
+		wantEnded bool
+	}{
+		{
+			name: "every other day", rule: "FREQ=DAILY;INTERVAL=2",
+			from: "2025-01-01 09:00", index: 1, after: "2025-01-01 09:00",
+			want: "2025-01-03 09:00", wantIndex: 2,
+		},
+		{
+			name: "weekdays skip the weekend", rule: "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+			from: "2025-01-03 07:30", index: 1, after: "2025-01-03 07:30", // Friday
+			want: "2025-01-06 07:30", wantIndex: 2,
+		},
+		{
+			name: "every other week keeps its week", rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+			from: "2025-01-09 18:00", index: 1, after: "2025-01-09 18:00", // Thursday
+			want: "2025-01-20 18:00", wantIndex: 2,
+		},
+		{
+			name: "weekly without days", rule: "FREQ=WEEKLY",
+			from: "2025-01-01 10:00", index: 3, after: "2025-01-01 10:00",
+			want: "2025-01-08 10:00", wantIndex: 4,
+		},
+		{
+			name: "monthly skips months without the day", rule: "FREQ=MONTHLY;BYMONTHDAY=31",
+			from: "2025-01-31 12:00", index: 1, after: "2025-01-31 12:00",
+			want: "2025-03-31 12:00", wantIndex: 2,
+		},
+		{
+			name: "monthly last day", rule: "FREQ=MONTHLY;BYMONTHDAY=-1",
+			from: "2025-01-31 12:00", index: 1, after: "2025-01-31 12:00",
+			want: "2025-02-28 12:00", wantIndex: 2,
+		},
+		{
+			name: "monthly defaults to the day of the first occurrence", rule: "FREQ=MONTHLY;INTERVAL=3",
+			from: "2025-01-15 08:00", index: 1, after: "2025-01-15 08:00",
+			want: "2025-04-15 08:00", wantIndex: 2,
+		},
+		{
+			name: "missed occurrences are skipped", rule: "FREQ=DAILY",
+			from: "2025-01-01 09:00", index: 1, after: "2025-01-04 10:00",
+			want: "2025-01-05 09:00", wantIndex: 5,
+		},
+		{
+			name: "count ends the recurrence", rule: "FREQ=DAILY;COUNT=3",
+			from: "2025-01-03 09:00", index: 3, after: "2025-01-03 09:00",
+			wantEnded: true,
+		},
+		{
+			name: "until date includes the whole day", rule: "FREQ=DAILY;UNTIL=20250102",
+			from: "2025-01-01 21:00", index: 1, after: "2025-01-01 21:00",
+			want: "2025-01-02 21:00", wantIndex: 2,
+		},
+		{
+			name: "until ends the recurrence", rule: "FREQ=DAILY;UNTIL=20250102T120000Z",
+			from: "2025-01-01 21:00", index: 1, after: "2025-01-01 21:00",
+			wantEnded: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.rule, err)
+			}
+
+			next, ok := rule.NextAfter(Occurrence{At: at(tt.from), Index: tt.index}, at(tt.after))
+			if tt.wantEnded {
+				if ok {
+					t.Fatalf("NextAfter() = %v, want the recurrence to have ended", next)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("NextAfter() ended, want %s", tt.want)
+			}
+			if !next.At.Equal(at(tt.want)) || next.Index != tt.wantIndex {
+				t.Errorf("NextAfter() = %s #%d, want %s #%d", next.At, next.Index, tt.want, tt.wantIndex)
+			}
+		})
+	}
+}
+
+func TestNextAfterKeepsLocalTimeAcrossDaylightSaving(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	rule, err := Parse("FREQ=DAILY")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from := time.Date(2025, 3, 29, 9, 0, 0, 0, loc)
+	next, ok := rule.NextAfter(Occurrence{At: from, Index: 1}, from)
+	if !ok {
+		t.Fatal("NextAfter() ended unexpectedly")
+	}
+	if want := time.Date(2025, 3, 30, 9, 0, 0, 0, loc); !next.At.Equal(want) {
+		t.Errorf("NextAfter() = %s, want %s", next.At, want)
+	}
+}
@@ -0,0 +1,368 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sriram15/progressor-todo-app/internal/connection"
+	"github.com/sriram15/progressor-todo-app/internal/database"
+)
+
+//go:embed blueprints/*.json
+var builtInBlueprintFiles embed.FS
+
+var (
+	ErrInvalidBlueprint  = errors.New("invalid blueprint")
+	ErrBuiltInBlueprint  = errors.New("built-in blueprints cannot be changed")
+	ErrBlueprintNotFound = errors.New("blueprint not found")
+)
+
+// CardTemplate describes a card that a blueprint creates.
+type CardTemplate struct {
+	Title         string   `json:"title"`
+	Description   string   `json:"description,omitempty"`
+	EstimatedMins uint     `json:"estimatedMins"`
+	Priority      int      `json:"priority,omitempty"`
+	Checklist     []string `json:"checklist,omitempty"`
+}
+
+// Blueprint describes a project to start in one go: its cards and the skills its tracked time
+// counts toward. Skills are matched by name and created when missing.
+type Blueprint struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Skills      []string       `json:"skills"`
+	Cards       []CardTemplate `json:"cards"`
+	// BuiltIn is set for the blueprints that ship with the app, which cannot be changed.
+	BuiltIn bool `json:"builtIn"`
+}
+
+// blueprintDefinition is the part of a saved blueprint stored as JSON.
+type blueprintDefinition struct {
+	Skills []string       `json:"skills"`
+	Cards  []CardTemplate `json:"cards"`
+}
+
+type IBlueprintService interface {
+	GetBlueprints() ([]Blueprint, error)
+	SaveBlueprint(blueprint Blueprint) error
+	DeleteBlueprint(name string) error
+	InstantiateBlueprint(name string, projectName string) (*database.Project, error)
+}
+
+type BlueprintService struct {
+	ctx       context.Context
+	dbManager *connection.DBManager
+}
+
+func NewBlueprintService(dbManager *connection.DBManager) *BlueprintService {
+	return &BlueprintService{
+		ctx:       context.Background(),
+		dbManager: dbManager,
+	}
+}
+
+// GetBlueprints returns the built-in blueprints followed by the saved ones, each by name.
+func (b *BlueprintService) GetBlueprints() ([]Blueprint, error) {
+	blueprints, err := loadBuiltInBlueprints()
+	if err != nil {
+		return nil, err
+	}
+
+	queries := b.dbManager.Queries(b.ctx)
+	saved, err := queries.ListBlueprints(b.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blueprints: %w", err)
+	}
+	for _, row := range saved {
+		blueprint, err := blueprintFromRow(row)
+		if err != nil {
+			log.Printf("Skipping blueprint %q: %v", row.Name, err)
+			continue
+		}
+		blueprints = append(blueprints, blueprint)
+	}
+	return blueprints, nil
+}
+
+// SaveBlueprint stores a blueprint, replacing a saved blueprint of the same name. The names of
+// built-in blueprints are reserved.
+func (b *BlueprintService) SaveBlueprint(blueprint Blueprint) error {
+	blueprint.Name = strings.TrimSpace(blueprint.Name)
+	skills := make([]string, len(blueprint.Skills))
+	for i, skill := range blueprint.Skills {
+		skills[i] = strings.TrimSpace(skill)
+	}
+	blueprint.Skills = skills
+	if err := validateBlueprint(blueprint); err != nil {
+		return err
+	}
+	if _, builtIn, err := findBuiltInBlueprint(blueprint.Name); err != nil {
+		return err
+	} else if builtIn {
+		return ErrBuiltInBlueprint
+	}
+
+	definition, err := json.Marshal(blueprintDefinition{Skills: blueprint.Skills, Cards: blueprint.Cards})
+	if err != nil {
+		return fmt.Errorf("failed to encode blueprint: %w", err)
+	}
+
+	var description sql.NullString
+	if blueprint.Description != "" {
+		description = sql.NullString{Valid: true, String: blueprint.Description}
+	}
+
+	return b.dbManager.Execute(b.ctx, func(q *database.Queries) error {
+		_, err := q.UpsertBlueprint(b.ctx, database.UpsertBlueprintParams{
+			Name:        blueprint.Name,
+			Description: description,
+			Definition:  string(definition),
+		})
+		return err
+	})
+}
+
+func (b *BlueprintService) DeleteBlueprint(name string) error {
+	if _, builtIn, err := findBuiltInBlueprint(name); err != nil {
+		return err
+	} else if builtIn {
+		return ErrBuiltInBlueprint
+	}
+
+	return b.dbManager.Execute(b.ctx, func(q *database.Queries) error {
+		row, err := q.GetBlueprintByName(b.ctx, strings.TrimSpace(name))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrBlueprintNotFound
+			}
+			return err
+		}
+		return q.DeleteBlueprint(b.ctx, row.ID)
+	})
+}
+
+// InstantiateBlueprint creates a project from a blueprint in one transaction: the project, its
+// cards in the blueprint's order with their checklists, and its skill associations. projectName
+// defaults to the blueprint's name.
+func (b *BlueprintService) InstantiateBlueprint(name string, projectName string) (*database.Project, error) {
+	blueprint, ok, err := findBuiltInBlueprint(name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		row, err := b.dbManager.Queries(b.ctx).GetBlueprintByName(b.ctx, strings.TrimSpace(name))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrBlueprintNotFound
+			}
+			return nil, fmt.Errorf("failed to get blueprint: %w", err)
+		}
+		if blueprint, err = blueprintFromRow(row); err != nil {
+			return nil, err
+		}
+	}
+
+	projectName = strings.TrimSpace(projectName)
+	if projectName == "" {
+		projectName = blueprint.Name
+	}
+
+	var project database.Project
+	err = b.dbManager.Execute(b.ctx, func(q *database.Queries) error {
+		var err error
+		project, err = createProject(b.ctx, q, projectName)
+		if err != nil {
+			return err
+		}
+		if err := b.addBlueprintSkills(q, project.ID, blueprint.Skills); err != nil {
+			return err
+		}
+		return b.addBlueprintCards(q, project.ID, blueprint.Cards)
+	})
+	if err != nil {
+		log.Printf("Error instantiating blueprint %q: %v", blueprint.Name, err)
+		return nil, fmt.Errorf("failed to instantiate blueprint: %w", err)
+	}
+
+	log.Printf("Created project %d from blueprint %q", project.ID, blueprint.Name)
+	return &project, nil
+}
+
+// addBlueprintSkills associates a project with the named skills, creating the skills the user
+// does not have yet. Skills already linked to the project are skipped.
+func (b *BlueprintService) addBlueprintSkills(q *database.Queries, projectID int64, names []string) error {
+	skills, err := q.GetSkillsByUserID(b.ctx, userId)
+	if err != nil {
+		return err
+	}
+	projectSkills, err := q.GetSkillsForProject(b.ctx, projectID)
+	if err != nil {
+		return err
+	}
+	linked := make(map[int64]bool, len(projectSkills))
+	for _, skill := range projectSkills {
+		linked[skill.ID] = true
+	}
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		i := slices.IndexFunc(skills, func(s database.UserSkill) bool { return strings.EqualFold(s.Name, name) })
+		if i < 0 {
+			skill, err := q.CreateSkill(b.ctx, database.CreateSkillParams{UserID: userId, Name: name})
+			if err != nil {
+				return err
+			}
+			skills = append(skills, skill)
+			i = len(skills) - 1
+		}
+		if linked[skills[i].ID] {
+			continue
+		}
+		if err := q.AddProjectSkill(b.ctx, database.AddProjectSkillParams{ProjectID: projectID, SkillID: skills[i].ID}); err != nil {
+			return err
+		}
+		linked[skills[i].ID] = true
+	}
+	return nil
+}
+
+func (b *BlueprintService) addBlueprintCards(q *database.Queries, projectID int64, templates []CardTemplate) error {
+	for i, template := range templates {
+		var description sql.NullString
+		if template.Description != "" {
+			description = sql.NullString{Valid: true, String: template.Description}
+		}
+
+		cardID, err := q.CreateCard(b.ctx, database.CreateCardParams{
+			Title:         template.Title,
+			Description:   description,
+			Status:        int64(Todo),
+			Projectid:     projectID,
+			Estimatedmins: int64(template.EstimatedMins),
+			Priority:      int64(template.Priority),
+			Rank:          float64(i + 1),
+		})
+		if err != nil {
+			return err
+		}
+
+		for position, title := range template.Checklist {
+			_, err := q.CreateChecklistItem(b.ctx, database.CreateChecklistItemParams{
+				Cardid:   cardID,
+				Title:    title,
+				Position: int64(position),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		err = q.CreateCardEvent(b.ctx, database.CreateCardEventParams{
+			Cardid:    cardID,
+			Eventtype: CardEventCreated,
+			Createdat: time.Now().UTC(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadBuiltInBlueprints reads the blueprints embedded in the app, ordered by name.
+func loadBuiltInBlueprints() ([]Blueprint, error) {
+	files, err := fs.Glob(builtInBlueprintFiles, "blueprints/*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	blueprints := make([]Blueprint, 0, len(files))
+	for _, file := range files {
+		data, err := builtInBlueprintFiles.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var blueprint Blueprint
+		if err := json.Unmarshal(data, &blueprint); err != nil {
+			return nil, fmt.Errorf("failed to read built-in blueprint %s: %w", file, err)
+		}
+		blueprint.BuiltIn = true
+		blueprints = append(blueprints, blueprint)
+	}
+	slices.SortFunc(blueprints, func(a, b Blueprint) int { return strings.Compare(a.Name, b.Name) })
+	return blueprints, nil
+}
+
+func findBuiltInBlueprint(name string) (Blueprint, bool, error) {
+	blueprints, err := loadBuiltInBlueprints()
+	if err != nil {
+		return Blueprint{}, false, err
+	}
+	name = strings.TrimSpace(name)
+	for _, blueprint := range blueprints {
+		if strings.EqualFold(blueprint.Name, name) {
+			return blueprint, true, nil
+		}
+	}
+	return Blueprint{}, false, nil
+}
+
+func blueprintFromRow(row database.Blueprint) (Blueprint, error) {
+	var definition blueprintDefinition
+	if err := json.Unmarshal([]byte(row.Definition), &definition); err != nil {
+		return Blueprint{}, fmt.Errorf("%w: %v", ErrInvalidBlueprint, err)
+	}
+	return Blueprint{
+		Name:        row.Name,
+		Description: row.Description.String,
+		Skills:      definition.Skills,
+		Cards:       definition.Cards,
+	}, nil
+}
+
+// validateBlueprint checks that a blueprint has a name and at least one card, that its cards are
+// well formed and that it names each skill once, ignoring case.
+func validateBlueprint(blueprint Blueprint) error {
+	if strings.TrimSpace(blueprint.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidBlueprint)
+	}
+	if len(blueprint.Cards) == 0 {
+		return fmt.Errorf("%w: at least one card is required", ErrInvalidBlueprint)
+	}
+	for i, card := range blueprint.Cards {
+		if strings.TrimSpace(card.Title) == "" {
+			return fmt.Errorf("%w: card %d has no title", ErrInvalidBlueprint, i+1)
+		}
+		if card.Priority < PriorityNone || card.Priority > PriorityUrgent {
+			return fmt.Errorf("%w: card %q: %v", ErrInvalidBlueprint, card.Title, ErrInvalidPriority)
+		}
+		if slices.ContainsFunc(card.Checklist, func(item string) bool { return strings.TrimSpace(item) == "" }) {
+			return fmt.Errorf("%w: card %q has an empty checklist item", ErrInvalidBlueprint, card.Title)
+		}
+	}
+	seen := make(map[string]bool, len(blueprint.Skills))
+	for _, skill := range blueprint.Skills {
+		key := strings.ToLower(strings.TrimSpace(skill))
+		if key == "" {
+			return fmt.Errorf("%w: skill names cannot be empty", ErrInvalidBlueprint)
+		}
+		if seen[key] {
+			return fmt.Errorf("%w: skill %q is listed more than once", ErrInvalidBlueprint, strings.TrimSpace(skill))
+		}
+		seen[key] = true
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuiltInBlueprintsAreValid(t *testing.T) {
+	blueprints, err := loadBuiltInBlueprints()
+	if err != nil {
+		t.Fatalf("loadBuiltInBlueprints() unexpected error: %v", err)
+	}
+	if len(blueprints) == 0 {
+		t.Fatal("loadBuiltInBlueprints() found no blueprints")
+	}
+
+	names := make(map[string]bool)
+	for _, blueprint := range blueprints {
+		if err := validateBlueprint(blueprint); err != nil {
+			t.Errorf("built-in blueprint %q: %v", blueprint.Name, err)
+		}
+		if names[blueprint.Name] {
+			t.Errorf("built-in blueprint %q is defined twice", blueprint.Name)
+		}
+		names[blueprint.Name] = true
+	}
+}
+
+func TestValidateBlueprint(t *testing.T) {
+	card := CardTemplate{Title: "Read the docs", EstimatedMins: 30}
+
+	tests := []struct {
+		name      string
+		blueprint Blueprint
+		wantErr   bool
+	}{
+		{name: "valid", blueprint: Blueprint{Name: "Docs", Skills: []string{"Reading"}, Cards: []CardTemplate{card}}},
+		{name: "missing name", blueprint: Blueprint{Name: " ", Cards: []CardTemplate{card}}, wantErr: true},
+		{name: "no cards", blueprint: Blueprint{Name: "Docs"}, wantErr: true},
+		{name: "untitled card", blueprint: Blueprint{Name: "Docs", Cards: []CardTemplate{{EstimatedMins: 30}}}, wantErr: true},
+		{name: "invalid priority", blueprint: Blueprint{Name: "Docs", Cards: []CardTemplate{{Title: "Read", Priority: 9}}}, wantErr: true},
+		{name: "empty checklist item", blueprint: Blueprint{Name: "Docs", Cards: []CardTemplate{{Title: "Read", Checklist: []string{""}}}}, wantErr: true},
+		{name: "empty skill", blueprint: Blueprint{Name: "Docs", Skills: []string{""}, Cards: []CardTemplate{card}}, wantErr: true},
+		{name: "duplicate skill", blueprint: Blueprint{Name: "Docs", Skills: []string{"Go", " go"}, Cards: []CardTemplate{card}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBlueprint(tt.blueprint)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("validateBlueprint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidBlueprint) {
+				t.Errorf("validateBlueprint() error = %v, want ErrInvalidBlueprint", err)
+			}
+		})
+	}
+}
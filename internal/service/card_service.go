@@ -1,28 +1,86 @@
 package service
 
 import (
+	"cmp"
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"log"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/sriram15/progressor-todo-app/internal/connection"
 	"github.com/sriram15/progressor-todo-app/internal/database"
 	"github.com/sriram15/progressor-todo-app/internal/events"
+	"github.com/sriram15/progressor-todo-app/internal/recurrence"
 )
 
 var (
-	ErrorUnknown           = errors.New("unknown error")
-	ErrNotFound            = errors.New("not found")
-	ErrInvalidProject      = errors.New("invalid project")
-	ErrInvalidStatus       = errors.New("invalid status")
-	ErrInvalidUpdate       = errors.New("invalid update")
-	ErrCardTitleRequired   = errors.New("card title is required")
-	ErrCardTrackingStarted = errors.New("card tracking already in progress")
-	ErrCardTrackingStopped = errors.New("card tracking already stopped")
+	ErrorUnknown                = errors.New("unknown error")
+	ErrNotFound                 = errors.New("not found")
+	ErrInvalidProject           = errors.New("invalid project")
+	ErrInvalidStatus            = errors.New("invalid status")
+	ErrInvalidUpdate            = errors.New("invalid update")
+	ErrCardTitleRequired        = errors.New("card title is required")
+	ErrCardTrackingStarted      = errors.New("card tracking already in progress")
+	ErrCardTrackingStopped      = errors.New("card tracking already stopped")
+	ErrCardTrackingPaused       = errors.New("card tracking already paused")
+	ErrCardNotPaused            = errors.New("card tracking is not paused")
+	ErrInvalidIdleGap           = errors.New("idle gap must fall inside the running time entry")
+	ErrInvalidIdleGapResolution = errors.New("invalid idle gap resolution")
+	ErrChecklistTitleRequired   = errors.New("checklist item title is required")
+	ErrInvalidChecklistOrder    = errors.New("checklist order must list every item of the card once")
+	ErrInvalidTagMatch          = errors.New("tag match must be 'any' or 'all'")
+	ErrInvalidUpcomingDays      = errors.New("upcoming days must be between 1 and 366")
+	ErrInvalidPriority          = errors.New("priority must be between 0 and 4")
+	ErrInvalidCardSort          = errors.New("invalid card sort")
+	ErrInvalidMove              = errors.New("a card must be moved next to other cards of its project")
+	ErrCardBlocked              = errors.New("card is blocked by unfinished cards")
+	ErrInvalidDependency        = errors.New("a card can only depend on another card of its project")
+	ErrDependencyCycle          = errors.New("dependency would create a cycle")
+	ErrInvalidTargetProject     = errors.New("card is already in the target project")
+	ErrInvalidSkillAttribution  = errors.New("skill attribution must be 'keep' or 'move'")
+	ErrInvalidBulkOperation     = errors.New("invalid bulk card operation")
+	ErrInvalidBulkCardCount     = errors.New("a bulk card operation needs between 1 and 500 cards")
+	ErrDuplicateBulkCard        = errors.New("a bulk card operation lists a card more than once")
+	ErrTransitionNotAllowed     = errors.New("the project's workflow does not allow this status change")
 )
 
+// checklistItemExp is the partial EXP awarded the first time a checklist item is completed.
+const checklistItemExp = 2
+
+// How MoveCardToProject attributes the time already tracked on a card to skills. Keep leaves it
+// with the skills of the card's old project; move takes it from them and gives it to the skills
+// of the new project.
+const (
+	SkillAttributionKeep = "keep"
+	SkillAttributionMove = "move"
+)
+
+// Operations of BulkCardOperation.
+const (
+	BulkCardDone     = "done"
+	BulkCardMove     = "move"
+	BulkCardTag      = "tag"
+	BulkCardDelete   = "delete"
+	BulkCardEstimate = "estimate"
+)
+
+// maxBulkCards bounds the number of cards changed by one BulkCardOperation.
+const maxBulkCards = 500
+
+// Resolutions of an idle gap in a running time entry.
+const (
+	IdleGapKeep    = "keep"
+	IdleGapDiscard = "discard"
+	IdleGapSplit   = "split"
+)
+
+// CardStatus is whether a card is done. A card's place in its project's workflow is its
+// workflow status; its CardStatus follows from whether that status counts as done.
 type CardStatus int
 
 const (
@@ -31,24 +89,165 @@ const (
 	Active
 )
 
+// Card priorities, from none to urgent.
+const (
+	PriorityNone = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+	PriorityUrgent
+)
+
+// Types of the events in a card's history.
+const (
+	CardEventCreated           = "created"
+	CardEventUpdated           = "updated"
+	CardEventStatusChanged     = "status_changed"
+	CardEventMoved             = "moved"
+	CardEventProjectChanged    = "project_changed"
+	CardEventStarted           = "started"
+	CardEventStopped           = "stopped"
+	CardEventPaused            = "paused"
+	CardEventResumed           = "resumed"
+	CardEventIdleGapResolved   = "idle_gap_resolved"
+	CardEventChecklistAdded    = "checklist_item_added"
+	CardEventChecklistDone     = "checklist_item_done"
+	CardEventChecklistUndone   = "checklist_item_undone"
+	CardEventChecklistRemoved  = "checklist_item_removed"
+	CardEventChecklistOrdered  = "checklist_reordered"
+	CardEventTagAdded          = "tag_added"
+	CardEventTagRemoved        = "tag_removed"
+	CardEventDependencyAdded   = "dependency_added"
+	CardEventDependencyRemoved = "dependency_removed"
+	CardEventDeleted           = "deleted"
+	CardEventRestored          = "restored"
+)
+
+// maxUpcomingDays bounds the range of GetUpcomingCards.
+const maxUpcomingDays = 366
+
+// UpdateCardParams replaces the editable fields of a card. A nil DueAt or ScheduledFor clears it.
 type UpdateCardParams struct {
-	Title         string `json:"title"`
-	EstimatedMins int    `json:"estimatedMins"`
-	Description   string `json:"description"`
+	Title         string     `json:"title"`
+	EstimatedMins int        `json:"estimatedMins"`
+	Description   string     `json:"description"`
+	DueAt         *time.Time `json:"dueAt"`
+	ScheduledFor  *time.Time `json:"scheduledFor"`
+}
+
+// AddCardParams describes a new card. DueAt and ScheduledFor are optional.
+type AddCardParams struct {
+	Title         string     `json:"title"`
+	EstimatedMins uint       `json:"estimatedMins"`
+	Description   string     `json:"description"`
+	DueAt         *time.Time `json:"dueAt"`
+	ScheduledFor  *time.Time `json:"scheduledFor"`
+	Priority      int        `json:"priority"`
+}
+
+// Ways CardFilter.TagIDs are matched.
+const (
+	TagMatchAny = "any"
+	TagMatchAll = "all"
+)
+
+// Orders of CardFilter.Sort. Rank is the manual order set with MoveCard and the default.
+// Priority sorts the highest first, estimate the smallest first, and created and updated the
+// newest first.
+const (
+	CardSortRank     = "rank"
+	CardSortPriority = "priority"
+	CardSortEstimate = "estimate"
+	CardSortCreated  = "created"
+	CardSortUpdated  = "updated"
+)
+
+// CardFilter narrows the cards returned by GetAllFiltered. The zero value matches every card.
+type CardFilter struct {
+	TagIDs []int64 `json:"tagIds"`
+	// TagMatch is TagMatchAny (the default) to match cards with at least one of the tags, or
+	// TagMatchAll to match cards with every tag.
+	TagMatch string `json:"tagMatch"`
+	// Sort is one of the CardSort values; Reverse flips its direction.
+	Sort    string `json:"sort"`
+	Reverse bool   `json:"reverse"`
+}
+
+// CardDependencies lists the cards a card is blocked by and the cards it blocks.
+type CardDependencies struct {
+	BlockedBy []database.Card `json:"blockedBy"`
+	Blocks    []database.Card `json:"blocks"`
+}
+
+// BulkCardParams describes a BulkCardOperation on the cards of one project. Only the fields
+// used by Operation need to be set: TargetProjectID and SkillAttribution for a move, TagID for
+// tagging and EstimatedMins for a re-estimate.
+type BulkCardParams struct {
+	Operation        string `json:"operation"`
+	CardIDs          []uint `json:"cardIds"`
+	TargetProjectID  uint   `json:"targetProjectId"`
+	SkillAttribution string `json:"skillAttribution"`
+	TagID            int64  `json:"tagId"`
+	EstimatedMins    uint   `json:"estimatedMins"`
+}
+
+// BulkCardResult is the outcome of a BulkCardOperation for one card. Error says why the card was
+// skipped when Ok is false.
+type BulkCardResult struct {
+	CardID uint   `json:"cardId"`
+	Ok     bool   `json:"ok"`
+	Error  string `json:"error,omitempty"`
 }
 
 const userId = 1
 
 type ICardService interface {
 	GetAll(projectId uint, status CardStatus) ([]database.ListCardsRow, error)
+	GetAllFiltered(projectId uint, status CardStatus, filter CardFilter) ([]database.ListCardsRow, error)
 	GetCardById(projectId uint, id uint) (*database.GetCardRow, error)
 	GetActiveTimeEntry(projectId uint, id uint) (*database.TimeEntry, error)
 	DeleteCard(projectId uint, id uint) error
+	GetTrash() ([]database.Card, error)
+	RestoreCard(projectId uint, id uint) error
+	PurgeCard(projectId uint, id uint) error
+	PurgeExpiredTrash() (int, error)
 	UpdateCard(projectId uint, id uint, updateCardParam UpdateCardParams) error
 	UpdateCardStatus(projectId uint, id uint, status CardStatus) error
+	ForceUpdateCardStatus(projectId uint, id uint, status CardStatus) error
+	MoveCardToWorkflowStatus(projectId uint, id uint, workflowStatusId int64) error
+	ForceMoveCardToWorkflowStatus(projectId uint, id uint, workflowStatusId int64) error
 	AddCard(projectId uint, cardTitle string, estimatedMins uint) error
+	AddCardWithParams(projectId uint, params AddCardParams) error
+	GetTodayCards() ([]database.Card, error)
+	GetUpcomingCards(days uint) ([]database.Card, error)
+	GetOverdueCards() ([]database.Card, error)
+	SetCardPriority(projectId uint, id uint, priority int) error
+	MoveCard(projectId uint, id uint, beforeId uint, afterId uint) error
+	MoveCardToProject(projectId uint, id uint, targetProjectId uint, skillAttribution string) error
+	DuplicateCard(projectId uint, id uint, targetProjectId uint) (*database.GetCardRow, error)
+	BulkCardOperation(projectId uint, params BulkCardParams) ([]BulkCardResult, error)
+	SetCardRecurrence(projectId uint, id uint, rule string) error
+	GetRecurrenceHistory(projectId uint, id uint) ([]database.ListRecurrenceHistoryRow, error)
+	GetCardHistory(projectId uint, id uint) ([]database.CardEvent, error)
 	StartCard(projectId uint, id uint) error
+	ForceStartCard(projectId uint, id uint) error
 	StopCard(projectId uint, id uint) error
+	StopCardAt(projectId uint, id uint, endTime time.Time) error
+	PauseCard(projectId uint, id uint) error
+	ResumeCard(projectId uint, id uint) error
+	GetActivePauses(projectId uint, id uint) ([]database.TimeEntryPause, error)
+	ResolveIdleGap(projectId uint, id uint, gapStart time.Time, gapEnd time.Time, resolution string) error
+	GetChecklist(projectId uint, cardId uint) ([]database.ChecklistItem, error)
+	AddChecklistItem(projectId uint, cardId uint, title string) (*database.ChecklistItem, error)
+	ToggleChecklistItem(projectId uint, cardId uint, itemId uint) error
+	ReorderChecklistItems(projectId uint, cardId uint, itemIds []int64) error
+	DeleteChecklistItem(projectId uint, cardId uint, itemId uint) error
+	GetCardTags(projectId uint, cardId uint) ([]database.Tag, error)
+	AddCardTag(projectId uint, cardId uint, tagId int64) error
+	RemoveCardTag(projectId uint, cardId uint, tagId int64) error
+	GetCardDependencies(projectId uint, id uint) (*CardDependencies, error)
+	AddCardDependency(projectId uint, id uint, blockedById uint) error
+	RemoveCardDependency(projectId uint, id uint, blockedById uint) error
 	Cleanup() error
 }
 
@@ -56,27 +255,116 @@ type CardService struct {
 	ctx                   context.Context
 	projectService        IProjectService
 	taskCompletionService ITaskCompletionService
+	streakService         IStreakService
+	settingService        ISettingService
 	dbManager             *connection.DBManager
 	eventBus              *events.EventBus
 }
 
-func NewCardService(projectService IProjectService, taskCompletionService ITaskCompletionService, dbManager *connection.DBManager, eventBus *events.EventBus) *CardService {
+func NewCardService(projectService IProjectService, taskCompletionService ITaskCompletionService, streakService IStreakService, settingService ISettingService, dbManager *connection.DBManager, eventBus *events.EventBus) *CardService {
 	return &CardService{
 		ctx:                   context.Background(),
 		projectService:        projectService,
 		taskCompletionService: taskCompletionService,
+		streakService:         streakService,
+		settingService:        settingService,
 		dbManager:             dbManager,
 		eventBus:              eventBus,
 	}
 }
 
 func (c *CardService) GetAll(projectId uint, status CardStatus) ([]database.ListCardsRow, error) {
+	return c.GetAllFiltered(projectId, status, CardFilter{})
+}
+
+// GetAllFiltered lists the cards of a project with the given status that match filter.
+func (c *CardService) GetAllFiltered(projectId uint, status CardStatus, filter CardFilter) ([]database.ListCardsRow, error) {
 	if _, err := c.projectService.IsValidProject(projectId); err != nil {
 		return nil, err
 	}
+	if filter.TagMatch != "" && filter.TagMatch != TagMatchAny && filter.TagMatch != TagMatchAll {
+		return nil, ErrInvalidTagMatch
+	}
+	compare, err := cardComparison(filter.Sort, filter.Reverse)
+	if err != nil {
+		return nil, err
+	}
 
 	queries := c.dbManager.Queries(c.ctx)
-	return queries.ListCards(c.ctx, database.ListCardsParams{Projectid: int64(projectId), Status: int64(status)})
+	cards, err := queries.ListCards(c.ctx, database.ListCardsParams{
+		DoneStatus: int64(Done),
+		Projectid:  int64(projectId),
+		Status:     int64(status),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(filter.TagIDs) > 0 {
+		cards, err = c.filterByTags(queries, projectId, cards, filter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// ListCards returns the manual order, which breaks ties of the other orders.
+	slices.SortStableFunc(cards, compare)
+	return cards, nil
+}
+
+// filterByTags keeps the cards that carry the tags of filter.
+func (c *CardService) filterByTags(queries *database.Queries, projectId uint, cards []database.ListCardsRow, filter CardFilter) ([]database.ListCardsRow, error) {
+	cardTags, err := queries.ListCardTagsForProject(c.ctx, int64(projectId))
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[int64]bool, len(filter.TagIDs))
+	for _, tagId := range filter.TagIDs {
+		wanted[tagId] = true
+	}
+	matches := make(map[int64]int)
+	for _, cardTag := range cardTags {
+		if wanted[cardTag.Tagid] {
+			matches[cardTag.Cardid]++
+		}
+	}
+
+	required := 1
+	if filter.TagMatch == TagMatchAll {
+		required = len(wanted)
+	}
+	filtered := make([]database.ListCardsRow, 0, len(cards))
+	for _, card := range cards {
+		if matches[card.ID] >= required {
+			filtered = append(filtered, card)
+		}
+	}
+	return filtered, nil
+}
+
+// cardComparison returns the comparison that sorts cards by sortBy.
+func cardComparison(sortBy string, reverse bool) (func(a, b database.ListCardsRow) int, error) {
+	var compare func(a, b database.ListCardsRow) int
+	switch sortBy {
+	case "", CardSortRank:
+		compare = func(a, b database.ListCardsRow) int { return cmp.Compare(a.Rank, b.Rank) }
+	case CardSortPriority:
+		compare = func(a, b database.ListCardsRow) int { return cmp.Compare(b.Priority, a.Priority) }
+	case CardSortEstimate:
+		compare = func(a, b database.ListCardsRow) int { return cmp.Compare(a.Estimatedmins, b.Estimatedmins) }
+	case CardSortCreated:
+		compare = func(a, b database.ListCardsRow) int { return b.Createdat.Time.Compare(a.Createdat.Time) }
+	case CardSortUpdated:
+		compare = func(a, b database.ListCardsRow) int { return b.Updatedat.Time.Compare(a.Updatedat.Time) }
+	default:
+		return nil, ErrInvalidCardSort
+	}
+
+	if reverse {
+		return func(a, b database.ListCardsRow) int { return compare(b, a) }, nil
+	}
+	return compare, nil
 }
 
 func (c *CardService) GetCardById(projectId uint, id uint) (*database.GetCardRow, error) {
@@ -110,17 +398,177 @@ func (c *CardService) GetActiveTimeEntry(projectId uint, id uint) (*database.Tim
 	return &res, nil
 }
 
+// DeleteCard moves a card to the trash. A card being tracked is stopped first, and its tracked
+// time and EXP are taken off its skills and the user's level until the card is restored.
 func (c *CardService) DeleteCard(projectId uint, id uint) error {
 	if _, err := c.projectService.IsValidProject(projectId); err != nil {
 		return err
 	}
 
+	var stoppedEvent *events.CardStoppedEvent
+	var changedEvent events.TimeEntryChangedEvent
+	var levelChanged *events.LevelChangedEvent
+	err := c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
+		card, err := q.GetCard(c.ctx, database.GetCardParams{ID: int64(id), Projectid: int64(projectId)})
+		if err != nil {
+			return err
+		}
+
+		trackedSeconds := card.TrackedSeconds
+		if card.Isactive {
+			event, err := c.stopCardLogic(q, projectId, id)
+			if err != nil {
+				return err
+			}
+			stoppedEvent = &event
+			trackedSeconds += int64(event.TimeSpent / time.Second)
+		}
+
+		if err := c.trashCardLogic(q, card.CardID); err != nil {
+			return err
+		}
+		levelChanged, err = c.taskCompletionService.WithdrawCardExperience(q, []int64{card.CardID}, userId)
+		if err != nil {
+			return err
+		}
+		changedEvent = newTimeEntryChangedEvent(card, -trackedSeconds)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if stoppedEvent != nil {
+		c.eventBus.Publish(events.CardStoppedTopic, *stoppedEvent)
+		log.Printf("Published CardStoppedEvent: %+v", *stoppedEvent)
+	}
+	if changedEvent.Delta != 0 {
+		c.eventBus.Publish(events.TimeEntryChangedTopic, changedEvent)
+		log.Printf("Published TimeEntryChangedEvent: %+v", changedEvent)
+	}
+	c.taskCompletionService.PublishLevelChanged(levelChanged)
+	return nil
+}
+
+func (c *CardService) trashCardLogic(q *database.Queries, cardId int64) error {
+	err := q.TrashCard(c.ctx, database.TrashCardParams{
+		DeletedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		ID:        cardId,
+	})
+	if err != nil {
+		return err
+	}
+	return c.recordCardEvent(q, cardId, CardEventDeleted)
+}
+
+// GetTrash returns the trashed cards of every project, most recently deleted first.
+func (c *CardService) GetTrash() ([]database.Card, error) {
+	queries := c.dbManager.Queries(c.ctx)
+	cards, err := queries.ListTrashedCards(c.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trashed cards: %w", err)
+	}
+	return cards, nil
+}
+
+// RestoreCard takes a card out of the trash and gives its tracked time and EXP back to its
+// skills and the user's level.
+func (c *CardService) RestoreCard(projectId uint, id uint) error {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return err
+	}
+
+	var changedEvent events.TimeEntryChangedEvent
+	var levelChanged *events.LevelChangedEvent
+	err := c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
+		_, err := q.GetTrashedCard(c.ctx, database.GetTrashedCardParams{ID: int64(id), Projectid: int64(projectId)})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotFound
+			}
+			return err
+		}
+		if err := q.RestoreCard(c.ctx, int64(id)); err != nil {
+			return err
+		}
+		if err := c.recordCardEvent(q, int64(id), CardEventRestored); err != nil {
+			return err
+		}
+		levelChanged, err = c.taskCompletionService.ReturnCardExperience(q, int64(id), userId)
+		if err != nil {
+			return err
+		}
+
+		card, err := q.GetCard(c.ctx, database.GetCardParams{ID: int64(id), Projectid: int64(projectId)})
+		if err != nil {
+			return err
+		}
+		changedEvent = newTimeEntryChangedEvent(card, card.TrackedSeconds)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if changedEvent.Delta != 0 {
+		c.eventBus.Publish(events.TimeEntryChangedTopic, changedEvent)
+		log.Printf("Published TimeEntryChangedEvent: %+v", changedEvent)
+	}
+	c.taskCompletionService.PublishLevelChanged(levelChanged)
+	return nil
+}
+
+// PurgeCard permanently deletes a trashed card with its time entries, completions, checklist
+// and tags. Its EXP already left the user's level when it was trashed. Cards that are not in
+// the trash cannot be purged.
+func (c *CardService) PurgeCard(projectId uint, id uint) error {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return err
+	}
+
 	return c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
-		return q.DeleteCard(c.ctx, database.DeleteCardParams{
-			ID:        int64(id),
-			Projectid: int64(projectId),
-		})
+		card, err := q.GetTrashedCard(c.ctx, database.GetTrashedCardParams{ID: int64(id), Projectid: int64(projectId)})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotFound
+			}
+			return err
+		}
+		return c.purgeCard(q, card)
+	})
+}
+
+// PurgeExpiredTrash permanently deletes the cards that have been in the trash for longer than
+// the trash_retention_days setting, returning how many were purged.
+func (c *CardService) PurgeExpiredTrash() (int, error) {
+	retentionDays, err := c.settingService.GetIntSetting("trash_retention_days")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read trash retention: %w", err)
+	}
+	cutoff := time.Now().UTC().AddDate(0, 0, -int(retentionDays))
+
+	purged := 0
+	err = c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
+		cards, err := q.ListCardsTrashedBefore(c.ctx, sql.NullTime{Time: cutoff, Valid: true})
+		if err != nil {
+			return err
+		}
+		for _, card := range cards {
+			if err := c.purgeCard(q, card); err != nil {
+				return err
+			}
+		}
+		purged = len(cards)
+		return nil
 	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge expired trash: %w", err)
+	}
+
+	if purged > 0 {
+		log.Printf("Purged %d cards from the trash", purged)
+	}
+	return purged, nil
 }
 
 func (c *CardService) UpdateCard(projectId uint, id uint, updateCardParam UpdateCardParams) error {
@@ -146,7 +594,7 @@ func (c *CardService) UpdateCard(projectId uint, id uint, updateCardParam Update
 			description = sql.NullString{Valid: true, String: updateCardParam.Description}
 		}
 
-		return q.UpdateCard(c.ctx, database.UpdateCardParams{
+		err = q.UpdateCard(c.ctx, database.UpdateCardParams{
 			Title:         updateCardParam.Title,
 			Description:   description,
 			ID:            card.CardID,
@@ -155,15 +603,66 @@ func (c *CardService) UpdateCard(projectId uint, id uint, updateCardParam Update
 			Estimatedmins: int64(updateCardParam.EstimatedMins),
 			Completedat:   card.Completedat,
 		})
+		if err != nil {
+			return err
+		}
+
+		dueAt := nullTime(updateCardParam.DueAt)
+		scheduledFor := nullTime(updateCardParam.ScheduledFor)
+		err = q.UpdateCardSchedule(c.ctx, database.UpdateCardScheduleParams{
+			DueAt:        dueAt,
+			ScheduledFor: scheduledFor,
+			ID:           card.CardID,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = c.recordCardEvent(q, card.CardID, CardEventUpdated,
+			cardFieldChange{"title", textValue(card.Title), textValue(updateCardParam.Title)},
+			cardFieldChange{"description", card.Description, description},
+			cardFieldChange{"estimatedMins", intValue(card.Estimatedmins), intValue(int64(updateCardParam.EstimatedMins))},
+			cardFieldChange{"dueAt", timeValue(card.DueAt), timeValue(dueAt)},
+			cardFieldChange{"scheduledFor", timeValue(card.ScheduledFor), timeValue(scheduledFor)},
+		)
+		if err != nil {
+			return err
+		}
+
+		// A new due date gets its own reminder.
+		if dueAt.Valid != card.DueAt.Valid || !dueAt.Time.Equal(card.DueAt.Time) {
+			return q.ClearCardDueReminder(c.ctx, card.CardID)
+		}
+		return nil
 	})
 }
 
+// UpdateCardStatus marks a card done or not done. A card already in a workflow status of that
+// kind stays there; otherwise it moves to the first such status of its project. A card blocked
+// by unfinished cards cannot be marked done; ForceUpdateCardStatus does so anyway.
 func (c *CardService) UpdateCardStatus(projectId uint, id uint, status CardStatus) error {
+	return c.updateCardStatus(projectId, id, status, false)
+}
+
+func (c *CardService) ForceUpdateCardStatus(projectId uint, id uint, status CardStatus) error {
+	return c.updateCardStatus(projectId, id, status, true)
+}
+
+func (c *CardService) updateCardStatus(projectId uint, id uint, status CardStatus, force bool) error {
 	if _, err := c.projectService.IsValidProject(projectId); err != nil {
 		return err
 	}
+	if status != Todo && status != Done {
+		return ErrInvalidStatus
+	}
 
-	return c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
+	var streakBonusExp int64
+	if status == Done {
+		streakBonusExp = c.streakBonusForCompletion(id)
+	}
+
+	var levelUp *events.LevelUpEvent
+	err := c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
 		card, err := q.GetCard(c.ctx, database.GetCardParams{
 			ID:        int64(id),
 			Projectid: int64(projectId),
@@ -172,74 +671,1315 @@ func (c *CardService) UpdateCardStatus(projectId uint, id uint, status CardStatu
 			return err
 		}
 
-		if status != Todo && status != Done {
-			return ErrInvalidStatus
+		if status == Done && !force {
+			if err := c.checkNotBlocked(q, card.CardID); err != nil {
+				return err
+			}
 		}
 
-		completedAt := sql.NullTime{}
-		if status == Done {
-			completedAt = sql.NullTime{Valid: true, Time: time.Now().UTC()}
+		target, err := c.workflowStatusFor(q, card, status)
+		if err != nil {
+			return err
 		}
+		levelUp, err = c.updateCardStatusLogic(q, card, target, streakBonusExp)
+		return err
+	})
+	if err != nil {
+		return err
+	}
 
-		err = q.UpdateCard(c.ctx, database.UpdateCardParams{
-			Title:         card.Title,
-			Description:   card.Description,
-			ID:            card.CardID,
-			Status:        int64(status),
-			Trackedmins:   card.Trackedmins,
-			Estimatedmins: card.Estimatedmins,
-			Completedat:   completedAt,
+	c.taskCompletionService.PublishLevelUp(levelUp)
+	return nil
+}
+
+// MoveCardToWorkflowStatus moves a card to another status of its project's workflow, if the
+// workflow allows the transition. Moving into a status that counts as done completes the card,
+// which a card blocked by unfinished cards cannot be unless ForceMoveCardToWorkflowStatus is used.
+func (c *CardService) MoveCardToWorkflowStatus(projectId uint, id uint, workflowStatusId int64) error {
+	return c.moveCardToWorkflowStatus(projectId, id, workflowStatusId, false)
+}
+
+func (c *CardService) ForceMoveCardToWorkflowStatus(projectId uint, id uint, workflowStatusId int64) error {
+	return c.moveCardToWorkflowStatus(projectId, id, workflowStatusId, true)
+}
+
+func (c *CardService) moveCardToWorkflowStatus(projectId uint, id uint, workflowStatusId int64, force bool) error {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return err
+	}
+
+	queries := c.dbManager.Queries(c.ctx)
+	target, err := getWorkflowStatus(c.ctx, queries, int64(projectId), workflowStatusId)
+	if err != nil {
+		return err
+	}
+
+	var streakBonusExp int64
+	if target.Isdone {
+		streakBonusExp = c.streakBonusForCompletion(id)
+	}
+
+	var levelUp *events.LevelUpEvent
+	err = c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
+		card, err := q.GetCard(c.ctx, database.GetCardParams{
+			ID:        int64(id),
+			Projectid: int64(projectId),
 		})
 		if err != nil {
 			return err
 		}
 
-		if status == Done {
-			baseExp := int64(10)
-			timeBonusExp := int64(card.Trackedmins / 5)
-			streakBonusExp := int64(0)
+		target, err := getWorkflowStatus(c.ctx, q, int64(projectId), workflowStatusId)
+		if err != nil {
+			return err
+		}
+		if target.Isdone && !force {
+			if err := c.checkNotBlocked(q, card.CardID); err != nil {
+				return err
+			}
+		}
+
+		levelUp, err = c.updateCardStatusLogic(q, card, target, streakBonusExp)
+		return err
+	})
+	if err != nil {
+		return err
+	}
 
-			_, err := q.GetTaskCompletion(c.ctx, database.GetTaskCompletionParams{
-				Cardid: card.CardID,
-				Userid: userId,
-			})
+	c.taskCompletionService.PublishLevelUp(levelUp)
+	return nil
+}
 
-			if errors.Is(err, sql.ErrNoRows) {
-				_, err = q.CreateTaskCompletion(c.ctx, database.CreateTaskCompletionParams{
-					Cardid:         card.CardID,
-					Userid:         userId,
-					Baseexp:        baseExp,
-					Timebonusexp:   timeBonusExp,
-					Streakbonusexp: streakBonusExp,
-					Totalexp:       baseExp + timeBonusExp + streakBonusExp,
-				})
-				if err != nil {
-					return err
-				}
-			}
+// streakBonusForCompletion reads the streak bonus before the transaction that completes a card;
+// a failure here should not block completing the card.
+func (c *CardService) streakBonusForCompletion(id uint) int64 {
+	bonus, err := c.streakService.StreakBonusForCompletion()
+	if err != nil {
+		log.Printf("Error calculating streak bonus for card %d: %v", id, err)
+		return 0
+	}
+	return bonus
+}
+
+// workflowStatusFor returns the workflow status a card is placed in when it is marked done or
+// not done: its current status if that is already of the right kind, otherwise the first status
+// of its project that is.
+func (c *CardService) workflowStatusFor(q *database.Queries, card database.GetCardRow, status CardStatus) (database.WorkflowStatus, error) {
+	statuses, err := q.ListWorkflowStatuses(c.ctx, card.Projectid)
+	if err != nil {
+		return database.WorkflowStatus{}, err
+	}
+	target, ok := pickWorkflowStatus(statuses, card.WorkflowStatusId, status)
+	if !ok {
+		return database.WorkflowStatus{}, ErrWorkflowNeedsOpenAndDone
+	}
+	return target, nil
+}
+
+// updateCardStatusLogic contains the core logic for moving a card to a workflow status, designed
+// to be used within a transaction. The transition is checked before anything is written. A card
+// moving into a done status from an open one records its completion once and creates the next
+// occurrence of a repeating card.
+func (c *CardService) updateCardStatusLogic(q *database.Queries, card database.GetCardRow, target database.WorkflowStatus, streakBonusExp int64) (*events.LevelUpEvent, error) {
+	var levelUp *events.LevelUpEvent
+
+	var current database.WorkflowStatus
+	if card.WorkflowStatusId.Valid {
+		var err error
+		current, err = q.GetWorkflowStatus(c.ctx, database.GetWorkflowStatusParams{
+			ID:        card.WorkflowStatusId.Int64,
+			Projectid: card.Projectid,
+		})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+	}
+	transitions, err := q.ListWorkflowTransitions(c.ctx, card.Projectid)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkWorkflowTransition(current.ID, target.ID, transitions); err != nil {
+		return nil, err
+	}
+
+	status := Todo
+	if target.Isdone {
+		status = Done
+	}
+	completing := status == Done && CardStatus(card.Status) != Done
+
+	completedAt := sql.NullTime{}
+	if completing {
+		completedAt = sql.NullTime{Valid: true, Time: time.Now().UTC()}
+	} else if status == Done {
+		completedAt = card.Completedat
+	}
+
+	err = q.UpdateCard(c.ctx, database.UpdateCardParams{
+		Title:         card.Title,
+		Description:   card.Description,
+		ID:            card.CardID,
+		Status:        int64(status),
+		Trackedmins:   card.Trackedmins,
+		Estimatedmins: card.Estimatedmins,
+		Completedat:   completedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = q.UpdateCardWorkflowStatus(c.ctx, database.UpdateCardWorkflowStatusParams{
+		WorkflowStatusId: sql.NullInt64{Valid: true, Int64: target.ID},
+		ID:               card.CardID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var currentName sql.NullString
+	if current.ID != 0 {
+		currentName = textValue(current.Name)
+	}
+	err = c.recordCardEvent(q, card.CardID, CardEventStatusChanged,
+		cardFieldChange{"status", textValue(cardStatusName(CardStatus(card.Status))), textValue(cardStatusName(status))},
+		cardFieldChange{"workflowStatus", currentName, textValue(target.Name)})
+	if err != nil {
+		return nil, err
+	}
+
+	if completing {
+		baseExp := int64(10)
+		timeBonusExp := card.TrackedSeconds / 300
+
+		_, err := q.GetTaskCompletion(c.ctx, database.GetTaskCompletionParams{
+			Cardid: card.CardID,
+			Userid: userId,
+		})
+
+		if errors.Is(err, sql.ErrNoRows) {
+			_, levelUp, err = c.taskCompletionService.RecordCompletion(q, card.CardID, userId, baseExp, timeBonusExp, streakBonusExp)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if card.RecurrenceRule.Valid {
+			if err := c.createNextOccurrence(q, card, completedAt.Time); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return levelUp, nil
+}
+
+func (c *CardService) AddCard(projectId uint, cardTitle string, estimatedMins uint) error {
+	return c.AddCardWithParams(projectId, AddCardParams{Title: cardTitle, EstimatedMins: estimatedMins})
+}
+
+func (c *CardService) AddCardWithParams(projectId uint, params AddCardParams) error {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return err
+	}
+
+	if params.Title == "" {
+		return ErrCardTitleRequired
+	}
+	if params.Priority < PriorityNone || params.Priority > PriorityUrgent {
+		return ErrInvalidPriority
+	}
+
+	var description sql.NullString
+	if params.Description != "" {
+		description = sql.NullString{Valid: true, String: params.Description}
+	}
+
+	card := database.CreateCardParams{
+		Title:         params.Title,
+		Description:   description,
+		Status:        int64(Todo),
+		Projectid:     int64(projectId),
+		Estimatedmins: int64(params.EstimatedMins),
+		DueAt:         nullTime(params.DueAt),
+		ScheduledFor:  nullTime(params.ScheduledFor),
+		Priority:      int64(params.Priority),
+	}
+
+	return c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
+		// New cards go to the end of the manual order.
+		maxRank, err := q.GetMaxCardRank(c.ctx, card.Projectid)
+		if err != nil {
+			return err
+		}
+		card.Rank = maxRank + 1
+		id, err := q.CreateCard(c.ctx, card)
+		if err != nil {
+			return err
+		}
+		return c.recordCardEvent(q, id, CardEventCreated)
+	})
+}
+
+func (c *CardService) SetCardPriority(projectId uint, id uint, priority int) error {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return err
+	}
+	if priority < PriorityNone || priority > PriorityUrgent {
+		return ErrInvalidPriority
+	}
+
+	return c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
+		card, err := q.GetCard(c.ctx, database.GetCardParams{ID: int64(id), Projectid: int64(projectId)})
+		if err != nil {
+			return ErrNotFound
+		}
+		err = q.UpdateCardPriority(c.ctx, database.UpdateCardPriorityParams{
+			Priority: int64(priority),
+			ID:       card.CardID,
+		})
+		if err != nil {
+			return err
+		}
+		return c.recordCardEvent(q, card.CardID, CardEventUpdated,
+			cardFieldChange{"priority", intValue(card.Priority), intValue(int64(priority))})
+	})
+}
+
+// MoveCard changes the manual order of a project's cards by placing a card between beforeId,
+// the card to come right before it, and afterId, the card to come right after it, among the
+// cards of the same status. Either may be 0 to move the card to the start or end. Only the moved
+// card is renumbered, unless its neighbours' ranks are too close together to fit another between
+// them.
+func (c *CardService) MoveCard(projectId uint, id uint, beforeId uint, afterId uint) error {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return err
+	}
+	if (beforeId == 0 && afterId == 0) || beforeId == id || afterId == id {
+		return ErrInvalidMove
+	}
+
+	return c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
+		card, err := q.GetCard(c.ctx, database.GetCardParams{ID: int64(id), Projectid: int64(projectId)})
+		if err != nil {
+			return ErrNotFound
+		}
+
+		ranks, err := q.ListCardRanks(c.ctx, database.ListCardRanksParams{Projectid: card.Projectid, Status: card.Status})
+		if err != nil {
+			return err
+		}
+		ranks = slices.DeleteFunc(ranks, func(r database.ListCardRanksRow) bool { return r.ID == card.CardID })
+
+		rank, ok, err := rankBetween(ranks, int64(beforeId), int64(afterId))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			log.Printf("Renumbering card ranks of project %d", projectId)
+			for i := range ranks {
+				ranks[i].Rank = float64(i + 1)
+				err := q.UpdateCardRank(c.ctx, database.UpdateCardRankParams{Rank: ranks[i].Rank, ID: ranks[i].ID})
+				if err != nil {
+					return err
+				}
+			}
+			if rank, _, err = rankBetween(ranks, int64(beforeId), int64(afterId)); err != nil {
+				return err
+			}
+		}
+
+		if err := q.UpdateCardRank(c.ctx, database.UpdateCardRankParams{Rank: rank, ID: card.CardID}); err != nil {
+			return err
+		}
+		return c.recordCardEvent(q, card.CardID, CardEventMoved)
+	})
+}
+
+// rankBetween returns the rank that places a card between beforeId and afterId in ranks, which
+// is in manual order. The two must be next to each other; a zero id stands for the start or end
+// of the list. ok is false when the neighbours' ranks are too close together to put another rank
+// between them.
+func rankBetween(ranks []database.ListCardRanksRow, beforeId int64, afterId int64) (rank float64, ok bool, err error) {
+	indexOf := func(id int64) int {
+		return slices.IndexFunc(ranks, func(r database.ListCardRanksRow) bool { return r.ID == id })
+	}
+
+	beforeIdx, afterIdx := -1, len(ranks)
+	if beforeId != 0 {
+		if beforeIdx = indexOf(beforeId); beforeIdx < 0 {
+			return 0, false, ErrInvalidMove
+		}
+	}
+	if afterId != 0 {
+		if afterIdx = indexOf(afterId); afterIdx < 0 {
+			return 0, false, ErrInvalidMove
+		}
+	}
+	switch {
+	case beforeId == 0:
+		beforeIdx = afterIdx - 1
+	case afterId == 0:
+		afterIdx = beforeIdx + 1
+	}
+	if afterIdx != beforeIdx+1 {
+		return 0, false, ErrInvalidMove
+	}
+
+	switch {
+	case beforeIdx < 0:
+		return ranks[afterIdx].Rank - 1, true, nil
+	case afterIdx >= len(ranks):
+		return ranks[beforeIdx].Rank + 1, true, nil
+	}
+	lower, upper := ranks[beforeIdx].Rank, ranks[afterIdx].Rank
+	rank = lower + (upper-lower)/2
+	return rank, rank > lower && rank < upper, nil
+}
+
+// MoveCardToProject moves a card to the end of another project with its checklist, tags, time
+// entries and completions. skillAttribution decides whether the time already tracked stays with
+// the old project's skills or moves to the new project's. Dependencies only link cards of one
+// project, so the card's dependencies are removed. A card being tracked cannot be moved.
+func (c *CardService) MoveCardToProject(projectId uint, id uint, targetProjectId uint, skillAttribution string) error {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return err
+	}
+	if _, err := c.projectService.IsValidProject(targetProjectId); err != nil {
+		return err
+	}
+	if projectId == targetProjectId {
+		return ErrInvalidTargetProject
+	}
+	if skillAttribution != SkillAttributionKeep && skillAttribution != SkillAttributionMove {
+		return ErrInvalidSkillAttribution
+	}
+
+	var changedEvents []events.TimeEntryChangedEvent
+	err := c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
+		card, err := q.GetCard(c.ctx, database.GetCardParams{ID: int64(id), Projectid: int64(projectId)})
+		if err != nil {
+			return ErrNotFound
+		}
+		if card.Isactive {
+			return ErrCardTrackingStarted
+		}
+
+		target, err := getProjectForUpdate(c.ctx, q, int64(targetProjectId))
+		if err != nil {
+			return err
+		}
+		if err := c.moveCardToProjectLogic(q, card, target); err != nil {
+			return err
+		}
+
+		if skillAttribution == SkillAttributionMove && card.TrackedSeconds > 0 {
+			removed := newTimeEntryChangedEvent(card, -card.TrackedSeconds)
+			added := newTimeEntryChangedEvent(card, card.TrackedSeconds)
+			added.ProjectID = target.ID
+			changedEvents = []events.TimeEntryChangedEvent{removed, added}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, changedEvent := range changedEvents {
+		c.eventBus.Publish(events.TimeEntryChangedTopic, changedEvent)
+		log.Printf("Published TimeEntryChangedEvent: %+v", changedEvent)
+	}
+	return nil
+}
+
+func (c *CardService) moveCardToProjectLogic(q *database.Queries, card database.GetCardRow, target database.Project) error {
+	source, err := getProjectForUpdate(c.ctx, q, card.Projectid)
+	if err != nil {
+		return err
+	}
+
+	maxRank, err := q.GetMaxCardRank(c.ctx, target.ID)
+	if err != nil {
+		return err
+	}
+	err = q.UpdateCardProject(c.ctx, database.UpdateCardProjectParams{
+		Projectid: target.ID,
+		Rank:      maxRank + 1,
+		ID:        card.CardID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := q.DeleteDependenciesOfCard(c.ctx, card.CardID); err != nil {
+		return err
+	}
+	if err := q.DeleteDependenciesOnCard(c.ctx, card.CardID); err != nil {
+		return err
+	}
+
+	return c.recordCardEvent(q, card.CardID, CardEventProjectChanged,
+		cardFieldChange{"project", textValue(source.Name), textValue(target.Name)})
+}
+
+// DuplicateCard copies a card to the end of targetProjectId, which may be the card's own
+// project. The copy is a new open card with the same details, tags and unchecked checklist. Its
+// tracked time starts at zero, and it is neither repeating nor linked by dependencies.
+func (c *CardService) DuplicateCard(projectId uint, id uint, targetProjectId uint) (*database.GetCardRow, error) {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return nil, err
+	}
+	if _, err := c.projectService.IsValidProject(targetProjectId); err != nil {
+		return nil, err
+	}
+
+	var duplicate database.GetCardRow
+	err := c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
+		card, err := q.GetCard(c.ctx, database.GetCardParams{ID: int64(id), Projectid: int64(projectId)})
+		if err != nil {
+			return ErrNotFound
+		}
+
+		maxRank, err := q.GetMaxCardRank(c.ctx, int64(targetProjectId))
+		if err != nil {
+			return err
+		}
+		duplicateId, err := q.CreateCard(c.ctx, database.CreateCardParams{
+			Title:         card.Title,
+			Description:   card.Description,
+			Status:        int64(Todo),
+			Projectid:     int64(targetProjectId),
+			Estimatedmins: card.Estimatedmins,
+			DueAt:         card.DueAt,
+			ScheduledFor:  card.ScheduledFor,
+			Priority:      card.Priority,
+			Rank:          maxRank + 1,
+		})
+		if err != nil {
+			return err
+		}
+
+		tags, err := q.ListTagsForCard(c.ctx, card.CardID)
+		if err != nil {
+			return err
+		}
+		for _, tag := range tags {
+			if err := q.AddCardTag(c.ctx, database.AddCardTagParams{Cardid: duplicateId, Tagid: tag.ID}); err != nil {
+				return err
+			}
+		}
+
+		items, err := q.ListChecklistItems(c.ctx, card.CardID)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			_, err := q.CreateChecklistItem(c.ctx, database.CreateChecklistItemParams{
+				Cardid:   duplicateId,
+				Title:    item.Title,
+				Position: item.Position,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		err = c.recordCardEvent(q, duplicateId, CardEventCreated,
+			cardFieldChange{"duplicatedFrom", sql.NullString{}, intValue(card.CardID)})
+		if err != nil {
+			return err
+		}
+
+		duplicate, err = q.GetCard(c.ctx, database.GetCardParams{ID: duplicateId, Projectid: int64(targetProjectId)})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &duplicate, nil
+}
+
+// BulkCardOperation applies one operation to many cards of a project in a single transaction.
+// Each card may be listed only once. A card that is missing, blocked or not allowed by the
+// workflow (when marking done) or being tracked (when moving or deleting) is skipped and
+// reported in its result; any other failure rolls back the whole operation. The changes are
+// announced by one CardsBulkChangedEvent rather than an event per card.
+func (c *CardService) BulkCardOperation(projectId uint, params BulkCardParams) ([]BulkCardResult, error) {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return nil, err
+	}
+	if len(params.CardIDs) == 0 || len(params.CardIDs) > maxBulkCards {
+		return nil, ErrInvalidBulkCardCount
+	}
+	seen := make(map[uint]bool, len(params.CardIDs))
+	for _, id := range params.CardIDs {
+		if seen[id] {
+			return nil, ErrDuplicateBulkCard
+		}
+		seen[id] = true
+	}
+
+	var streakBonusExp int64
+	switch params.Operation {
+	case BulkCardDone:
+		bonus, err := c.streakService.StreakBonusForCompletion()
+		if err != nil {
+			log.Printf("Error calculating streak bonus for bulk completion: %v", err)
+		} else {
+			streakBonusExp = bonus
+		}
+	case BulkCardMove:
+		if _, err := c.projectService.IsValidProject(params.TargetProjectID); err != nil {
+			return nil, err
+		}
+		if params.TargetProjectID == projectId {
+			return nil, ErrInvalidTargetProject
+		}
+		if params.SkillAttribution != SkillAttributionKeep && params.SkillAttribution != SkillAttributionMove {
+			return nil, ErrInvalidSkillAttribution
+		}
+	case BulkCardTag, BulkCardDelete, BulkCardEstimate:
+	default:
+		return nil, ErrInvalidBulkOperation
+	}
+
+	results := make([]BulkCardResult, len(params.CardIDs))
+	var levelUp *events.LevelUpEvent
+	var levelChanged *events.LevelChangedEvent
+	var bulkEvent events.CardsBulkChangedEvent
+	err := c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
+		levelUp = nil
+		bulkEvent = events.CardsBulkChangedEvent{
+			Operation:  params.Operation,
+			ProjectID:  int64(projectId),
+			UserID:     userId,
+			TimeDeltas: make(map[int64]time.Duration),
+		}
+
+		var target database.Project
+		var tag database.Tag
+		var err error
+		switch params.Operation {
+		case BulkCardMove:
+			target, err = getProjectForUpdate(c.ctx, q, int64(params.TargetProjectID))
+		case BulkCardTag:
+			tag, err = getTag(c.ctx, q, params.TagID)
+		}
+		if err != nil {
+			return err
+		}
+
+		for i, id := range params.CardIDs {
+			results[i] = BulkCardResult{CardID: id}
+
+			card, err := q.GetCard(c.ctx, database.GetCardParams{ID: int64(id), Projectid: int64(projectId)})
+			if errors.Is(err, sql.ErrNoRows) {
+				results[i].Error = ErrNotFound.Error()
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			switch params.Operation {
+			case BulkCardDone:
+				if err = c.checkNotBlocked(q, card.CardID); err != nil {
+					break
+				}
+				var target database.WorkflowStatus
+				if target, err = c.workflowStatusFor(q, card, Done); err != nil {
+					break
+				}
+				var cardLevelUp *events.LevelUpEvent
+				cardLevelUp, err = c.updateCardStatusLogic(q, card, target, streakBonusExp)
+				levelUp = mergeLevelUps(levelUp, cardLevelUp)
+			case BulkCardMove:
+				if card.Isactive {
+					err = ErrCardTrackingStarted
+					break
+				}
+				if err = c.moveCardToProjectLogic(q, card, target); err == nil && params.SkillAttribution == SkillAttributionMove {
+					bulkEvent.TimeDeltas[card.Projectid] -= time.Duration(card.TrackedSeconds) * time.Second
+					bulkEvent.TimeDeltas[target.ID] += time.Duration(card.TrackedSeconds) * time.Second
+				}
+			case BulkCardTag:
+				err = c.addCardTagLogic(q, card.CardID, tag)
+			case BulkCardDelete:
+				if card.Isactive {
+					err = ErrCardTrackingStarted
+					break
+				}
+				if err = c.trashCardLogic(q, card.CardID); err == nil {
+					bulkEvent.TimeDeltas[card.Projectid] -= time.Duration(card.TrackedSeconds) * time.Second
+				}
+			case BulkCardEstimate:
+				err = c.updateCardEstimateLogic(q, card, int64(params.EstimatedMins))
+			}
+
+			// Skipped cards fail these checks before anything is written.
+			if errors.Is(err, ErrCardBlocked) || errors.Is(err, ErrCardTrackingStarted) || errors.Is(err, ErrTransitionNotAllowed) {
+				results[i].Error = err.Error()
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			results[i].Ok = true
+			bulkEvent.CardIDs = append(bulkEvent.CardIDs, card.CardID)
+		}
+
+		if params.Operation == BulkCardDelete {
+			levelChanged, err = c.taskCompletionService.WithdrawCardExperience(q, bulkEvent.CardIDs, userId)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bulkEvent.CardIDs) > 0 {
+		bulkEvent.ChangedAt = time.Now().UTC()
+		c.eventBus.Publish(events.CardsBulkChangedTopic, bulkEvent)
+		log.Printf("Published CardsBulkChangedEvent: %+v", bulkEvent)
+	}
+	c.taskCompletionService.PublishLevelUp(levelUp)
+	c.taskCompletionService.PublishLevelChanged(levelChanged)
+	return results, nil
+}
+
+func (c *CardService) updateCardEstimateLogic(q *database.Queries, card database.GetCardRow, estimatedMins int64) error {
+	err := q.UpdateCard(c.ctx, database.UpdateCardParams{
+		Title:         card.Title,
+		Description:   card.Description,
+		ID:            card.CardID,
+		Status:        card.Status,
+		Trackedmins:   card.Trackedmins,
+		Estimatedmins: estimatedMins,
+		Completedat:   card.Completedat,
+	})
+	if err != nil {
+		return err
+	}
+	return c.recordCardEvent(q, card.CardID, CardEventUpdated,
+		cardFieldChange{"estimatedMins", intValue(card.Estimatedmins), intValue(estimatedMins)})
+}
+
+// mergeLevelUps combines the level ups of several completions into one, from the level before
+// the first to the level after the last.
+func mergeLevelUps(merged *events.LevelUpEvent, next *events.LevelUpEvent) *events.LevelUpEvent {
+	if merged == nil {
+		return next
+	}
+	if next == nil {
+		return merged
+	}
+	combined := *next
+	combined.PreviousLevel = merged.PreviousLevel
+	return &combined
+}
+
+// GetTodayCards lists the open cards of active projects that are due or scheduled today, in the
+// local time zone. Cards due earlier today are also reported by GetOverdueCards.
+func (c *CardService) GetTodayCards() ([]database.Card, error) {
+	start := startOfLocalDay(time.Now())
+	return c.listPlannedCards(start, start.AddDate(0, 0, 1))
+}
+
+// GetUpcomingCards lists the open cards of active projects that are due or scheduled in the given
+// number of days after today.
+func (c *CardService) GetUpcomingCards(days uint) ([]database.Card, error) {
+	if days == 0 || days > maxUpcomingDays {
+		return nil, ErrInvalidUpcomingDays
+	}
+
+	start := startOfLocalDay(time.Now()).AddDate(0, 0, 1)
+	return c.listPlannedCards(start, start.AddDate(0, 0, int(days)))
+}
+
+// GetOverdueCards lists the open cards of active projects whose due date has passed, oldest first.
+func (c *CardService) GetOverdueCards() ([]database.Card, error) {
+	queries := c.dbManager.Queries(c.ctx)
+	return queries.ListOverdueCards(c.ctx, database.ListOverdueCardsParams{
+		Status: int64(Todo),
+		Now:    sql.NullTime{Valid: true, Time: time.Now().UTC()},
+	})
+}
+
+// SetCardRecurrence makes a card repeat by an RRULE such as "FREQ=WEEKLY;BYDAY=MO,WE,FR", or
+// stops it repeating when rule is empty. A card becomes the first occurrence of a new series
+// unless it already belongs to one.
+func (c *CardService) SetCardRecurrence(projectId uint, id uint, rule string) error {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return err
+	}
+
+	var recurrenceRule sql.NullString
+	if strings.TrimSpace(rule) != "" {
+		parsed, err := recurrence.Parse(rule)
+		if err != nil {
+			return err
+		}
+		recurrenceRule = sql.NullString{Valid: true, String: parsed.String()}
+	}
+
+	return c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
+		card, err := q.GetCard(c.ctx, database.GetCardParams{ID: int64(id), Projectid: int64(projectId)})
+		if err != nil {
+			return ErrNotFound
+		}
+
+		seriesId := card.RecurrenceSeriesId
+		if !seriesId.Valid && recurrenceRule.Valid {
+			seriesId = sql.NullInt64{Valid: true, Int64: card.CardID}
+		}
+		err = q.UpdateCardRecurrence(c.ctx, database.UpdateCardRecurrenceParams{
+			RecurrenceRule:     recurrenceRule,
+			RecurrenceSeriesId: seriesId,
+			RecurrenceIndex:    card.RecurrenceIndex,
+			ID:                 card.CardID,
+		})
+		if err != nil {
+			return err
+		}
+		return c.recordCardEvent(q, card.CardID, CardEventUpdated,
+			cardFieldChange{"recurrenceRule", card.RecurrenceRule, recurrenceRule})
+	})
+}
+
+// GetRecurrenceHistory lists every occurrence in the series of a repeating card with its
+// completion, first occurrence first. Cards that never repeated have no history.
+func (c *CardService) GetRecurrenceHistory(projectId uint, id uint) ([]database.ListRecurrenceHistoryRow, error) {
+	card, err := c.GetCardById(projectId, id)
+	if err != nil {
+		return nil, err
+	}
+	if !card.RecurrenceSeriesId.Valid {
+		return []database.ListRecurrenceHistoryRow{}, nil
+	}
+
+	queries := c.dbManager.Queries(c.ctx)
+	return queries.ListRecurrenceHistory(c.ctx, database.ListRecurrenceHistoryParams{
+		Userid:             userId,
+		RecurrenceSeriesId: card.RecurrenceSeriesId,
+	})
+}
+
+// GetCardHistory returns the events recorded for a card, oldest first.
+func (c *CardService) GetCardHistory(projectId uint, id uint) ([]database.CardEvent, error) {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return nil, err
+	}
+
+	queries := c.dbManager.Queries(c.ctx)
+	card, err := queries.GetCard(c.ctx, database.GetCardParams{ID: int64(id), Projectid: int64(projectId)})
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	history, err := queries.ListCardEvents(c.ctx, card.CardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list card history: %w", err)
+	}
+	return history, nil
+}
+
+// createNextOccurrence adds the card following a completed repeating card, with the same
+// description, estimate, tags and checklist, and its dates moved to the first occurrence after
+// completedAt. Occurrences missed in between are skipped. Skills are attached to the project,
+// which the new card shares. Completing an occurrence again does not add another card.
+func (c *CardService) createNextOccurrence(q *database.Queries, card database.GetCardRow, completedAt time.Time) error {
+	rule, err := recurrence.Parse(card.RecurrenceRule.String)
+	if err != nil {
+		log.Printf("Error parsing recurrence rule of card %d: %v", card.CardID, err)
+		return nil
+	}
+
+	seriesId := card.RecurrenceSeriesId
+	if !seriesId.Valid {
+		seriesId = sql.NullInt64{Valid: true, Int64: card.CardID}
+	}
+	later, err := q.CountLaterOccurrences(c.ctx, database.CountLaterOccurrencesParams{
+		RecurrenceSeriesId: seriesId,
+		RecurrenceIndex:    card.RecurrenceIndex,
+	})
+	if err != nil {
+		return err
+	}
+	if later > 0 {
+		return nil
+	}
+
+	// The occurrence time is the scheduled time, else the due time, else when the card was added.
+	// Rules are evaluated in local time so weekdays and times of day match the user's calendar.
+	anchor := completedAt
+	switch {
+	case card.ScheduledFor.Valid:
+		anchor = card.ScheduledFor.Time
+	case card.DueAt.Valid:
+		anchor = card.DueAt.Time
+	case card.Createdat.Valid:
+		anchor = card.Createdat.Time
+	}
+	next, ok := rule.NextAfter(recurrence.Occurrence{At: anchor.Local(), Index: int(card.RecurrenceIndex)}, completedAt)
+	if !ok {
+		log.Printf("Recurrence of card %d ended after occurrence %d", card.CardID, card.RecurrenceIndex)
+		return nil
+	}
+
+	shift := next.At.Sub(anchor)
+	dueAt, scheduledFor := card.DueAt, card.ScheduledFor
+	if dueAt.Valid {
+		dueAt.Time = dueAt.Time.Add(shift).UTC()
+	}
+	if scheduledFor.Valid || !dueAt.Valid {
+		scheduledFor = sql.NullTime{Valid: true, Time: next.At.UTC()}
+	}
+
+	maxRank, err := q.GetMaxCardRank(c.ctx, card.Projectid)
+	if err != nil {
+		return err
+	}
+	nextCard, err := q.CreateCardOccurrence(c.ctx, database.CreateCardOccurrenceParams{
+		Title:              card.Title,
+		Description:        card.Description,
+		Status:             int64(Todo),
+		Projectid:          card.Projectid,
+		Estimatedmins:      card.Estimatedmins,
+		DueAt:              dueAt,
+		ScheduledFor:       scheduledFor,
+		RecurrenceRule:     card.RecurrenceRule,
+		RecurrenceSeriesId: seriesId,
+		RecurrenceIndex:    int64(next.Index),
+		Priority:           card.Priority,
+		Rank:               maxRank + 1,
+	})
+	if err != nil {
+		return err
+	}
+
+	tags, err := q.ListTagsForCard(c.ctx, card.CardID)
+	if err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		if err := q.AddCardTag(c.ctx, database.AddCardTagParams{Cardid: nextCard.ID, Tagid: tag.ID}); err != nil {
+			return err
+		}
+	}
+
+	items, err := q.ListChecklistItems(c.ctx, card.CardID)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		_, err := q.CreateChecklistItem(c.ctx, database.CreateChecklistItemParams{
+			Cardid:   nextCard.ID,
+			Title:    item.Title,
+			Position: item.Position,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Printf("Created occurrence %d of card %d as card %d", next.Index, card.CardID, nextCard.ID)
+	return c.recordCardEvent(q, nextCard.ID, CardEventCreated)
+}
+
+func (c *CardService) listPlannedCards(from time.Time, to time.Time) ([]database.Card, error) {
+	fromTime := sql.NullTime{Valid: true, Time: from.UTC()}
+	toTime := sql.NullTime{Valid: true, Time: to.UTC()}
+
+	queries := c.dbManager.Queries(c.ctx)
+	return queries.ListPlannedCards(c.ctx, database.ListPlannedCardsParams{
+		Status:        int64(Todo),
+		DueFrom:       fromTime,
+		DueTo:         toTime,
+		ScheduledFrom: fromTime,
+		ScheduledTo:   toTime,
+	})
+}
+
+// GetChecklist returns the checklist items of a card in display order.
+func (c *CardService) GetChecklist(projectId uint, cardId uint) ([]database.ChecklistItem, error) {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return nil, err
+	}
+
+	queries := c.dbManager.Queries(c.ctx)
+	if _, err := queries.GetCard(c.ctx, database.GetCardParams{ID: int64(cardId), Projectid: int64(projectId)}); err != nil {
+		return nil, ErrNotFound
+	}
+	return queries.ListChecklistItems(c.ctx, int64(cardId))
+}
+
+// AddChecklistItem appends an item to the end of a card's checklist.
+func (c *CardService) AddChecklistItem(projectId uint, cardId uint, title string) (*database.ChecklistItem, error) {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return nil, err
+	}
+
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil, ErrChecklistTitleRequired
+	}
+
+	var item database.ChecklistItem
+	err := c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
+		card, err := q.GetCard(c.ctx, database.GetCardParams{ID: int64(cardId), Projectid: int64(projectId)})
+		if err != nil {
+			return ErrNotFound
+		}
+
+		items, err := q.ListChecklistItems(c.ctx, card.CardID)
+		if err != nil {
+			return err
+		}
+
+		position := int64(0)
+		if len(items) > 0 {
+			position = items[len(items)-1].Position + 1
+		}
+		item, err = q.CreateChecklistItem(c.ctx, database.CreateChecklistItemParams{
+			Cardid:   card.CardID,
+			Title:    title,
+			Position: position,
+		})
+		if err != nil {
+			return err
+		}
+		return c.recordCardEvent(q, card.CardID, CardEventChecklistAdded,
+			cardFieldChange{"checklist", sql.NullString{}, textValue(title)})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// ToggleChecklistItem marks an item done or not done. The first time an item is completed it
+// earns checklistItemExp, which is not taken back when the item is unchecked.
+func (c *CardService) ToggleChecklistItem(projectId uint, cardId uint, itemId uint) error {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return err
+	}
+
+	var levelUp *events.LevelUpEvent
+	err := c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
+		item, err := c.getChecklistItem(q, projectId, cardId, itemId)
+		if err != nil {
+			return err
+		}
+
+		completedAt := sql.NullTime{}
+		if !item.Isdone {
+			completedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+		}
+		err = q.SetChecklistItemDone(c.ctx, database.SetChecklistItemDoneParams{
+			ID:          item.ID,
+			Isdone:      !item.Isdone,
+			Completedat: completedAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		eventType := CardEventChecklistDone
+		if item.Isdone {
+			eventType = CardEventChecklistUndone
+		}
+		err = c.recordCardEvent(q, item.Cardid, eventType, cardFieldChange{"checklist", sql.NullString{}, textValue(item.Title)})
+		if err != nil || item.Isdone {
+			return err
+		}
+
+		_, err = q.GetChecklistItemCompletion(c.ctx, database.GetChecklistItemCompletionParams{
+			ChecklistItemId: sql.NullInt64{Int64: item.ID, Valid: true},
+			Userid:          userId,
+		})
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		_, levelUp, err = c.taskCompletionService.RecordChecklistItemCompletion(q, item.Cardid, item.ID, userId, checklistItemExp)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	c.taskCompletionService.PublishLevelUp(levelUp)
+	return nil
+}
+
+// ReorderChecklistItems stores a new order of a card's checklist. itemIds must list every item
+// of the card exactly once.
+func (c *CardService) ReorderChecklistItems(projectId uint, cardId uint, itemIds []int64) error {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return err
+	}
+
+	return c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
+		card, err := q.GetCard(c.ctx, database.GetCardParams{ID: int64(cardId), Projectid: int64(projectId)})
+		if err != nil {
+			return ErrNotFound
+		}
+
+		items, err := q.ListChecklistItems(c.ctx, card.CardID)
+		if err != nil {
+			return err
+		}
+		if len(items) != len(itemIds) {
+			return ErrInvalidChecklistOrder
+		}
+
+		remaining := make(map[int64]bool, len(items))
+		for _, item := range items {
+			remaining[item.ID] = true
+		}
+		for position, itemId := range itemIds {
+			if !remaining[itemId] {
+				return ErrInvalidChecklistOrder
+			}
+			delete(remaining, itemId)
+
+			err := q.UpdateChecklistItemPosition(c.ctx, database.UpdateChecklistItemPositionParams{
+				ID:       itemId,
+				Position: int64(position),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return c.recordCardEvent(q, card.CardID, CardEventChecklistOrdered)
+	})
+}
+
+// DeleteChecklistItem removes an item from a card's checklist. EXP it already earned is kept.
+func (c *CardService) DeleteChecklistItem(projectId uint, cardId uint, itemId uint) error {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return err
+	}
+
+	return c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
+		item, err := c.getChecklistItem(q, projectId, cardId, itemId)
+		if err != nil {
+			return err
+		}
+		err = q.DeleteChecklistItem(c.ctx, database.DeleteChecklistItemParams{
+			ID:     item.ID,
+			Cardid: item.Cardid,
+		})
+		if err != nil {
+			return err
+		}
+		return c.recordCardEvent(q, item.Cardid, CardEventChecklistRemoved,
+			cardFieldChange{"checklist", textValue(item.Title), sql.NullString{}})
+	})
+}
+
+func (c *CardService) GetCardTags(projectId uint, cardId uint) ([]database.Tag, error) {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return nil, err
+	}
+
+	queries := c.dbManager.Queries(c.ctx)
+	if _, err := queries.GetCard(c.ctx, database.GetCardParams{ID: int64(cardId), Projectid: int64(projectId)}); err != nil {
+		return nil, ErrNotFound
+	}
+	return queries.ListTagsForCard(c.ctx, int64(cardId))
+}
+
+// AddCardTag labels a card with a tag. Adding a tag the card already has is a no-op.
+func (c *CardService) AddCardTag(projectId uint, cardId uint, tagId int64) error {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return err
+	}
+
+	return c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
+		card, err := q.GetCard(c.ctx, database.GetCardParams{ID: int64(cardId), Projectid: int64(projectId)})
+		if err != nil {
+			return ErrNotFound
+		}
+		tag, err := getTag(c.ctx, q, tagId)
+		if err != nil {
+			return err
+		}
+		return c.addCardTagLogic(q, card.CardID, tag)
+	})
+}
+
+func (c *CardService) addCardTagLogic(q *database.Queries, cardId int64, tag database.Tag) error {
+	hasTag, err := c.cardHasTag(q, cardId, tag.ID)
+	if err != nil || hasTag {
+		return err
+	}
+	if err := q.AddCardTag(c.ctx, database.AddCardTagParams{Cardid: cardId, Tagid: tag.ID}); err != nil {
+		return err
+	}
+	return c.recordCardEvent(q, cardId, CardEventTagAdded,
+		cardFieldChange{"tags", sql.NullString{}, textValue(tag.Name)})
+}
+
+func (c *CardService) RemoveCardTag(projectId uint, cardId uint, tagId int64) error {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return err
+	}
+
+	return c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
+		card, err := q.GetCard(c.ctx, database.GetCardParams{ID: int64(cardId), Projectid: int64(projectId)})
+		if err != nil {
+			return ErrNotFound
+		}
+		hasTag, err := c.cardHasTag(q, card.CardID, tagId)
+		if err != nil || !hasTag {
+			return err
+		}
+		tag, err := getTag(c.ctx, q, tagId)
+		if err != nil {
+			return err
+		}
+		if err := q.RemoveCardTag(c.ctx, database.RemoveCardTagParams{Cardid: card.CardID, Tagid: tagId}); err != nil {
+			return err
+		}
+		return c.recordCardEvent(q, card.CardID, CardEventTagRemoved,
+			cardFieldChange{"tags", textValue(tag.Name), sql.NullString{}})
+	})
+}
+
+// GetCardDependencies returns the cards a card is blocked by and the cards it blocks. Trashed
+// cards are left out.
+func (c *CardService) GetCardDependencies(projectId uint, id uint) (*CardDependencies, error) {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return nil, err
+	}
+
+	queries := c.dbManager.Queries(c.ctx)
+	card, err := queries.GetCard(c.ctx, database.GetCardParams{ID: int64(id), Projectid: int64(projectId)})
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	blockedBy, err := queries.ListBlockingCards(c.ctx, card.CardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blocking cards: %w", err)
+	}
+	blocks, err := queries.ListBlockedCards(c.ctx, card.CardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blocked cards: %w", err)
+	}
+	return &CardDependencies{BlockedBy: blockedBy, Blocks: blocks}, nil
+}
+
+// AddCardDependency marks a card as blocked by another card of the same project. A dependency
+// that would make a card wait, directly or through other cards, for itself is rejected.
+func (c *CardService) AddCardDependency(projectId uint, id uint, blockedById uint) error {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return err
+	}
+	if id == blockedById {
+		return ErrDependencyCycle
+	}
+
+	return c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
+		card, err := q.GetCard(c.ctx, database.GetCardParams{ID: int64(id), Projectid: int64(projectId)})
+		if err != nil {
+			return ErrNotFound
+		}
+		blocker, err := q.GetCard(c.ctx, database.GetCardParams{ID: int64(blockedById), Projectid: int64(projectId)})
+		if err != nil {
+			return ErrInvalidDependency
+		}
+
+		dependencies, err := q.ListProjectCardDependencies(c.ctx, card.Projectid)
+		if err != nil {
+			return err
+		}
+		exists := slices.ContainsFunc(dependencies, func(d database.CardDependency) bool {
+			return d.Cardid == card.CardID && d.Blockedbyid == blocker.CardID
+		})
+		if exists {
+			return nil
+		}
+		if createsDependencyCycle(dependencies, card.CardID, blocker.CardID) {
+			return ErrDependencyCycle
+		}
+
+		err = q.AddCardDependency(c.ctx, database.AddCardDependencyParams{Cardid: card.CardID, Blockedbyid: blocker.CardID})
+		if err != nil {
+			return err
+		}
+		return c.recordCardEvent(q, card.CardID, CardEventDependencyAdded,
+			cardFieldChange{"blockedBy", sql.NullString{}, textValue(blocker.Title)})
+	})
+}
+
+// RemoveCardDependency stops a card being blocked by another card. Removing a dependency that
+// does not exist is a no-op.
+func (c *CardService) RemoveCardDependency(projectId uint, id uint, blockedById uint) error {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return err
+	}
+
+	return c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
+		card, err := q.GetCard(c.ctx, database.GetCardParams{ID: int64(id), Projectid: int64(projectId)})
+		if err != nil {
+			return ErrNotFound
+		}
+
+		blockers, err := q.ListBlockingCards(c.ctx, card.CardID)
+		if err != nil {
+			return err
+		}
+		i := slices.IndexFunc(blockers, func(b database.Card) bool { return b.ID == int64(blockedById) })
+		if i < 0 {
+			return nil
 		}
-		return nil
+
+		err = q.RemoveCardDependency(c.ctx, database.RemoveCardDependencyParams{Cardid: card.CardID, Blockedbyid: blockers[i].ID})
+		if err != nil {
+			return err
+		}
+		return c.recordCardEvent(q, card.CardID, CardEventDependencyRemoved,
+			cardFieldChange{"blockedBy", textValue(blockers[i].Title), sql.NullString{}})
 	})
 }
 
-func (c *CardService) AddCard(projectId uint, cardTitle string, estimatedMins uint) error {
-	if cardTitle == "" {
-		return ErrCardTitleRequired
+// createsDependencyCycle reports whether making cardId blocked by blockedById would close a
+// cycle, that is whether cardId already blocks blockedById directly or through other cards.
+func createsDependencyCycle(dependencies []database.CardDependency, cardId int64, blockedById int64) bool {
+	blockers := make(map[int64][]int64)
+	for _, d := range dependencies {
+		blockers[d.Cardid] = append(blockers[d.Cardid], d.Blockedbyid)
 	}
 
-	card := database.CreateCardParams{
-		Title:         cardTitle,
-		Status:        int64(Todo),
-		Projectid:     int64(projectId),
-		Estimatedmins: int64(estimatedMins),
+	seen := make(map[int64]bool)
+	pending := []int64{blockedById}
+	for len(pending) > 0 {
+		id := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if id == cardId {
+			return true
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		pending = append(pending, blockers[id]...)
 	}
-
-	return c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
-		return q.CreateCard(c.ctx, card)
-	})
+	return false
 }
 
+// StartCard starts tracking a card, stopping the card tracked before it. A card blocked by
+// unfinished cards cannot be started; ForceStartCard starts it anyway.
 func (c *CardService) StartCard(projectId uint, id uint) error {
+	return c.startCard(projectId, id, false)
+}
+
+func (c *CardService) ForceStartCard(projectId uint, id uint) error {
+	return c.startCard(projectId, id, true)
+}
+
+func (c *CardService) startCard(projectId uint, id uint, force bool) error {
 	if _, err := c.projectService.IsValidProject(projectId); err != nil {
 		return err
 	}
@@ -255,6 +1995,11 @@ func (c *CardService) StartCard(projectId uint, id uint) error {
 		if card.Isactive {
 			return ErrCardTrackingStarted
 		}
+		if !force {
+			if err := c.checkNotBlocked(q, card.CardID); err != nil {
+				return err
+			}
+		}
 
 		activeCard, err := q.GetActiveCard(c.ctx)
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -292,13 +2037,19 @@ func (c *CardService) StartCard(projectId uint, id uint) error {
 }
 
 func (c *CardService) StopCard(projectId uint, id uint) error {
+	return c.StopCardAt(projectId, id, time.Now().UTC())
+}
+
+// StopCardAt stops tracking a card with its time entry ending at endTime rather than now. It is
+// used to close entries that were left running when the app was not.
+func (c *CardService) StopCardAt(projectId uint, id uint, endTime time.Time) error {
 	if _, err := c.projectService.IsValidProject(projectId); err != nil {
 		return err
 	}
 
 	var stoppedEvent events.CardStoppedEvent
 	err := c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
-		event, err := c.stopCardLogic(q, projectId, id)
+		event, err := c.stopCardLogicAt(q, projectId, id, endTime)
 		if err != nil {
 			return err
 		}
@@ -315,6 +2066,194 @@ func (c *CardService) StopCard(projectId uint, id uint) error {
 	return nil
 }
 
+// PauseCard suspends tracking of an active card without ending its time entry. Paused time is
+// left out of the entry's duration when the card is stopped.
+func (c *CardService) PauseCard(projectId uint, id uint) error {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return err
+	}
+
+	var pausedEvent events.CardPausedEvent
+	err := c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
+		card, activeTimeEntry, err := c.getTrackedEntry(q, projectId, id)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.GetOpenTimeEntryPause(c.ctx, activeTimeEntry.ID)
+		if err == nil {
+			return ErrCardTrackingPaused
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		pausedAt := time.Now().UTC()
+		err = q.CreateTimeEntryPause(c.ctx, database.CreateTimeEntryPauseParams{
+			Timeentryid: activeTimeEntry.ID,
+			Pausedat:    pausedAt,
+		})
+		if err != nil {
+			return err
+		}
+		if err := c.recordCardEvent(q, card.CardID, CardEventPaused); err != nil {
+			return err
+		}
+
+		pausedEvent = events.CardPausedEvent{
+			CardID:    card.CardID,
+			ProjectID: card.Projectid,
+			UserID:    userId,
+			PausedAt:  pausedAt,
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	c.eventBus.Publish(events.CardPausedTopic, pausedEvent)
+	log.Printf("Published CardPausedEvent: %+v", pausedEvent)
+	return nil
+}
+
+// ResumeCard continues tracking a paused card within the same time entry.
+func (c *CardService) ResumeCard(projectId uint, id uint) error {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return err
+	}
+
+	var resumedEvent events.CardResumedEvent
+	err := c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
+		card, activeTimeEntry, err := c.getTrackedEntry(q, projectId, id)
+		if err != nil {
+			return err
+		}
+
+		pause, err := q.GetOpenTimeEntryPause(c.ctx, activeTimeEntry.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrCardNotPaused
+		}
+		if err != nil {
+			return err
+		}
+
+		resumedAt := time.Now().UTC()
+		err = q.ResumeTimeEntryPause(c.ctx, database.ResumeTimeEntryPauseParams{
+			ID:        pause.ID,
+			Resumedat: sql.NullTime{Time: resumedAt, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		if err := c.recordCardEvent(q, card.CardID, CardEventResumed); err != nil {
+			return err
+		}
+
+		resumedEvent = events.CardResumedEvent{
+			CardID:    card.CardID,
+			ProjectID: card.Projectid,
+			UserID:    userId,
+			ResumedAt: resumedAt,
+			PausedFor: resumedAt.Sub(pause.Pausedat),
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	c.eventBus.Publish(events.CardResumedTopic, resumedEvent)
+	log.Printf("Published CardResumedEvent: %+v", resumedEvent)
+	return nil
+}
+
+// GetActivePauses returns the pauses of the card's running time entry. The last one is still
+// open while the card is paused.
+func (c *CardService) GetActivePauses(projectId uint, id uint) ([]database.TimeEntryPause, error) {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return nil, err
+	}
+
+	queries := c.dbManager.Queries(c.ctx)
+	_, activeTimeEntry, err := c.getTrackedEntry(queries, projectId, id)
+	if err != nil {
+		return nil, err
+	}
+	return queries.ListTimeEntryPauses(c.ctx, activeTimeEntry.ID)
+}
+
+// ResolveIdleGap applies the user's choice for a gap found in the running time entry of a card.
+// The gap can be kept as tracked time, discarded by recording it as a pause, or used to split
+// the entry: the time before the gap becomes a finished entry and tracking continues in a new
+// entry from the end of the gap.
+func (c *CardService) ResolveIdleGap(projectId uint, id uint, gapStart time.Time, gapEnd time.Time, resolution string) error {
+	if _, err := c.projectService.IsValidProject(projectId); err != nil {
+		return err
+	}
+
+	switch resolution {
+	case IdleGapKeep, IdleGapDiscard, IdleGapSplit:
+	default:
+		return ErrInvalidIdleGapResolution
+	}
+
+	gapStart, gapEnd = gapStart.UTC(), gapEnd.UTC()
+	var changedEvent *events.TimeEntryChangedEvent
+	err := c.dbManager.Execute(c.ctx, func(q *database.Queries) error {
+		card, activeTimeEntry, err := c.getTrackedEntry(q, projectId, id)
+		if err != nil {
+			return err
+		}
+		if gapStart.Before(activeTimeEntry.Starttime) || !gapEnd.After(gapStart) || gapEnd.After(time.Now().UTC()) {
+			return ErrInvalidIdleGap
+		}
+		if resolution == IdleGapKeep {
+			return nil
+		}
+
+		_, err = q.GetOpenTimeEntryPause(c.ctx, activeTimeEntry.ID)
+		if err == nil {
+			return ErrCardTrackingPaused
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		if resolution == IdleGapDiscard {
+			err = q.CreateTimeEntryPause(c.ctx, database.CreateTimeEntryPauseParams{
+				Timeentryid: activeTimeEntry.ID,
+				Pausedat:    gapStart,
+				Resumedat:   sql.NullTime{Time: gapEnd, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+		} else {
+			event, err := c.splitAtIdleGap(q, card, activeTimeEntry, gapStart, gapEnd)
+			if err != nil {
+				return err
+			}
+			changedEvent = &event
+		}
+		return c.recordCardEvent(q, card.CardID, CardEventIdleGapResolved,
+			cardFieldChange{"resolution", sql.NullString{}, textValue(resolution)})
+	})
+
+	if err != nil {
+		return err
+	}
+
+	// The finished part is not reported by a stopped event, so it is announced as a change.
+	if changedEvent != nil && changedEvent.Delta != 0 {
+		c.eventBus.Publish(events.TimeEntryChangedTopic, *changedEvent)
+		log.Printf("Published TimeEntryChangedEvent: %+v", *changedEvent)
+	}
+	return nil
+}
+
 func (c *CardService) Cleanup() error {
 	log.Println("Cleaning up active card if any...")
 	queries := c.dbManager.Queries(c.ctx)
@@ -340,6 +2279,13 @@ func (c *CardService) Cleanup() error {
 
 // stopCardLogic contains the core logic for stopping a card, designed to be used within a transaction.
 func (c *CardService) stopCardLogic(q *database.Queries, projectId uint, id uint) (events.CardStoppedEvent, error) {
+	return c.stopCardLogicAt(q, projectId, id, time.Now().UTC())
+}
+
+// stopCardLogicAt is stopCardLogic with an explicit end time. Running entries are those whose
+// end equals their start, so an end time that is not after the start is moved a second past it.
+// A pause still open at endTime is closed there, and paused time is not counted.
+func (c *CardService) stopCardLogicAt(q *database.Queries, projectId uint, id uint, endTime time.Time) (events.CardStoppedEvent, error) {
 	card, err := q.GetCard(c.ctx, database.GetCardParams{ID: int64(id), Projectid: int64(projectId)})
 	if err != nil {
 		return events.CardStoppedEvent{}, err
@@ -353,34 +2299,64 @@ func (c *CardService) stopCardLogic(q *database.Queries, projectId uint, id uint
 		return events.CardStoppedEvent{}, err
 	}
 
-	currentEndTime := time.Now().UTC()
-	duration := currentEndTime.Sub(activeTimeEntry.Starttime).Minutes()
+	currentEndTime := endTime.UTC()
+	if !currentEndTime.After(activeTimeEntry.Starttime) {
+		currentEndTime = activeTimeEntry.Starttime.Add(time.Second)
+	}
+
+	pauses, err := q.ListTimeEntryPauses(c.ctx, activeTimeEntry.ID)
+	if err != nil {
+		return events.CardStoppedEvent{}, err
+	}
+	for _, pause := range pauses {
+		if pause.Resumedat.Valid {
+			continue
+		}
+		resumedAt := currentEndTime
+		if resumedAt.Before(pause.Pausedat) {
+			resumedAt = pause.Pausedat
+		}
+		err = q.ResumeTimeEntryPause(c.ctx, database.ResumeTimeEntryPauseParams{
+			ID:        pause.ID,
+			Resumedat: sql.NullTime{Time: resumedAt, Valid: true},
+		})
+		if err != nil {
+			return events.CardStoppedEvent{}, err
+		}
+	}
+	seconds := trackedSeconds(activeTimeEntry.Starttime, currentEndTime) - pausedSeconds(pauses, activeTimeEntry.Starttime, currentEndTime)
 
 	err = q.UpdateActiveTimeEntry(c.ctx, database.UpdateActiveTimeEntryParams{
 		ID:       activeTimeEntry.ID,
 		Endtime:  currentEndTime,
-		Duration: int64(duration),
+		Duration: seconds,
 	})
 	if err != nil {
 		return events.CardStoppedEvent{}, err
 	}
 
-	newTrackedMins := card.Trackedmins + int64(duration)
+	newTrackedSeconds := card.TrackedSeconds + seconds
 	err = q.UpdateCardActive(c.ctx, database.UpdateCardActiveParams{
-		ID:          int64(id),
-		Isactive:    false,
-		Trackedmins: newTrackedMins,
+		ID:             int64(id),
+		Isactive:       false,
+		Trackedmins:    newTrackedSeconds / 60,
+		TrackedSeconds: newTrackedSeconds,
 	})
 	if err != nil {
 		return events.CardStoppedEvent{}, err
 	}
+	err = c.recordCardEvent(q, card.CardID, CardEventStopped,
+		cardFieldChange{"trackedSeconds", intValue(card.TrackedSeconds), intValue(newTrackedSeconds)})
+	if err != nil {
+		return events.CardStoppedEvent{}, err
+	}
 
-	log.Println("Card updated to inactive:", id, "with tracked mins:", newTrackedMins)
+	log.Println("Card updated to inactive:", id, "with tracked seconds:", newTrackedSeconds)
 	return events.CardStoppedEvent{
 		CardID:    card.CardID,
 		ProjectID: card.Projectid,
 		UserID:    userId,
-		TimeSpent: time.Duration(duration) * time.Minute,
+		TimeSpent: time.Duration(seconds) * time.Second,
 		StoppedAt: currentEndTime,
 	}, nil
 }
@@ -388,9 +2364,10 @@ func (c *CardService) stopCardLogic(q *database.Queries, projectId uint, id uint
 // startCardLogic contains the core logic for starting a card, designed to be used within a transaction.
 func (c *CardService) startCardLogic(q *database.Queries, card database.GetCardRow) (events.CardStartedEvent, error) {
 	err := q.UpdateCardActive(c.ctx, database.UpdateCardActiveParams{
-		ID:          card.CardID,
-		Isactive:    true,
-		Trackedmins: card.Trackedmins,
+		ID:             card.CardID,
+		Isactive:       true,
+		Trackedmins:    card.Trackedmins,
+		TrackedSeconds: card.TrackedSeconds,
 	})
 	if err != nil {
 		return events.CardStartedEvent{}, err
@@ -405,6 +2382,9 @@ func (c *CardService) startCardLogic(q *database.Queries, card database.GetCardR
 	if err != nil {
 		return events.CardStartedEvent{}, err
 	}
+	if err := c.recordCardEvent(q, card.CardID, CardEventStarted); err != nil {
+		return events.CardStartedEvent{}, err
+	}
 
 	return events.CardStartedEvent{
 		CardID:    card.CardID,
@@ -413,3 +2393,210 @@ func (c *CardService) startCardLogic(q *database.Queries, card database.GetCardR
 		StartedAt: currentStartTime,
 	}, nil
 }
+
+// getTrackedEntry loads an active card and its running time entry.
+func (c *CardService) getTrackedEntry(q *database.Queries, projectId uint, id uint) (database.GetCardRow, database.TimeEntry, error) {
+	card, err := q.GetCard(c.ctx, database.GetCardParams{ID: int64(id), Projectid: int64(projectId)})
+	if err != nil {
+		return database.GetCardRow{}, database.TimeEntry{}, ErrNotFound
+	}
+	if !card.Isactive {
+		return database.GetCardRow{}, database.TimeEntry{}, ErrCardTrackingStopped
+	}
+
+	activeTimeEntry, err := q.GetActiveTimeEntry(c.ctx, card.CardID)
+	if err != nil {
+		return database.GetCardRow{}, database.TimeEntry{}, err
+	}
+	return card, activeTimeEntry, nil
+}
+
+// splitAtIdleGap finishes the running time entry at gapStart and starts a new one at gapEnd,
+// designed to be used within a transaction. Pauses after the gap move to the new entry.
+func (c *CardService) splitAtIdleGap(q *database.Queries, card database.GetCardRow, activeTimeEntry database.TimeEntry, gapStart time.Time, gapEnd time.Time) (events.TimeEntryChangedEvent, error) {
+	if !gapStart.After(activeTimeEntry.Starttime) {
+		return events.TimeEntryChangedEvent{}, ErrInvalidIdleGap
+	}
+
+	pauses, err := q.ListTimeEntryPauses(c.ctx, activeTimeEntry.ID)
+	if err != nil {
+		return events.TimeEntryChangedEvent{}, err
+	}
+
+	seconds := trackedSeconds(activeTimeEntry.Starttime, gapStart) - pausedSeconds(pauses, activeTimeEntry.Starttime, gapStart)
+	err = q.UpdateActiveTimeEntry(c.ctx, database.UpdateActiveTimeEntryParams{
+		ID:       activeTimeEntry.ID,
+		Endtime:  gapStart,
+		Duration: seconds,
+	})
+	if err != nil {
+		return events.TimeEntryChangedEvent{}, err
+	}
+
+	next, err := q.CreateTimeEntry(c.ctx, database.CreateTimeEntryParams{
+		Cardid:    card.CardID,
+		Starttime: gapEnd,
+		Endtime:   gapEnd,
+	})
+	if err != nil {
+		return events.TimeEntryChangedEvent{}, err
+	}
+
+	err = q.MoveTimeEntryPauses(c.ctx, database.MoveTimeEntryPausesParams{
+		NewTimeEntryID: next.ID,
+		TimeEntryID:    activeTimeEntry.ID,
+		PausedFrom:     gapEnd,
+	})
+	if err != nil {
+		return events.TimeEntryChangedEvent{}, err
+	}
+
+	if err := q.RecalculateCardTrackedTime(c.ctx, card.CardID); err != nil {
+		return events.TimeEntryChangedEvent{}, err
+	}
+
+	log.Printf("Split time entry %d of card %d at an idle gap from %s to %s", activeTimeEntry.ID, card.CardID, gapStart, gapEnd)
+	return newTimeEntryChangedEvent(card, seconds), nil
+}
+
+// purgeCard deletes a card and every row that refers to it.
+func (c *CardService) purgeCard(q *database.Queries, card database.Card) error {
+	if err := q.DeleteTimeEntryPausesForCard(c.ctx, card.ID); err != nil {
+		return err
+	}
+	if err := q.DeleteTimeEntriesForCard(c.ctx, card.ID); err != nil {
+		return err
+	}
+	if err := q.DeleteTaskCompletionsForCard(c.ctx, card.ID); err != nil {
+		return err
+	}
+	if err := q.DeleteChecklistItemsForCard(c.ctx, card.ID); err != nil {
+		return err
+	}
+	if err := q.DeleteCardTagsForCard(c.ctx, card.ID); err != nil {
+		return err
+	}
+	if err := q.DeleteFocusSessionsForCard(c.ctx, card.ID); err != nil {
+		return err
+	}
+	if err := q.DeleteCardEventsForCard(c.ctx, card.ID); err != nil {
+		return err
+	}
+	if err := q.DeleteDependenciesOfCard(c.ctx, card.ID); err != nil {
+		return err
+	}
+	if err := q.DeleteDependenciesOnCard(c.ctx, card.ID); err != nil {
+		return err
+	}
+	return q.DeleteCard(c.ctx, database.DeleteCardParams{ID: card.ID, Projectid: card.Projectid})
+}
+
+// cardFieldChange is a change to one field of a card, with the values as text. An invalid value
+// stands for an empty field.
+type cardFieldChange struct {
+	field    string
+	oldValue sql.NullString
+	newValue sql.NullString
+}
+
+// recordCardEvent appends an event to a card's history, one row for each changed field. Changes
+// that leave a field as it was are skipped, so an update that changes nothing is not recorded.
+func (c *CardService) recordCardEvent(q *database.Queries, cardId int64, eventType string, changes ...cardFieldChange) error {
+	event := database.CreateCardEventParams{
+		Cardid:    cardId,
+		Eventtype: eventType,
+		Createdat: time.Now().UTC(),
+	}
+	if len(changes) == 0 {
+		return q.CreateCardEvent(c.ctx, event)
+	}
+
+	for _, change := range changes {
+		if change.oldValue == change.newValue {
+			continue
+		}
+		event.Field = textValue(change.field)
+		event.Oldvalue = change.oldValue
+		event.Newvalue = change.newValue
+		if err := q.CreateCardEvent(c.ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkNotBlocked returns ErrCardBlocked when a card waits for cards that are not done yet.
+func (c *CardService) checkNotBlocked(q *database.Queries, cardId int64) error {
+	openBlockers, err := q.CountOpenBlockers(c.ctx, database.CountOpenBlockersParams{Cardid: cardId, Status: int64(Done)})
+	if err != nil {
+		return err
+	}
+	if openBlockers > 0 {
+		return ErrCardBlocked
+	}
+	return nil
+}
+
+func (c *CardService) cardHasTag(q *database.Queries, cardId int64, tagId int64) (bool, error) {
+	tags, err := q.ListTagsForCard(c.ctx, cardId)
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(tags, func(t database.Tag) bool { return t.ID == tagId }), nil
+}
+
+// getChecklistItem loads a checklist item of a card in the given project.
+func (c *CardService) getChecklistItem(q *database.Queries, projectId uint, cardId uint, itemId uint) (database.ChecklistItem, error) {
+	card, err := q.GetCard(c.ctx, database.GetCardParams{ID: int64(cardId), Projectid: int64(projectId)})
+	if err != nil {
+		return database.ChecklistItem{}, ErrNotFound
+	}
+
+	item, err := q.GetChecklistItem(c.ctx, database.GetChecklistItemParams{ID: int64(itemId), Cardid: card.CardID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return database.ChecklistItem{}, ErrNotFound
+		}
+		return database.ChecklistItem{}, err
+	}
+	return item, nil
+}
+
+func startOfLocalDay(t time.Time) time.Time {
+	year, month, day := t.Local().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Valid: true, Time: t.UTC()}
+}
+
+func textValue(s string) sql.NullString {
+	return sql.NullString{Valid: true, String: s}
+}
+
+func intValue(n int64) sql.NullString {
+	return textValue(strconv.FormatInt(n, 10))
+}
+
+func timeValue(t sql.NullTime) sql.NullString {
+	if !t.Valid {
+		return sql.NullString{}
+	}
+	return textValue(t.Time.UTC().Format(time.RFC3339))
+}
+
+func cardStatusName(status CardStatus) string {
+	switch status {
+	case Todo:
+		return "todo"
+	case Done:
+		return "done"
+	case Active:
+		return "active"
+	}
+	return strconv.Itoa(int(status))
+}
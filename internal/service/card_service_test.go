@@ -0,0 +1,106 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sriram15/progressor-todo-app/internal/database"
+	"github.com/sriram15/progressor-todo-app/internal/events"
+)
+
+func TestRankBetween(t *testing.T) {
+	ranks := []database.ListCardRanksRow{
+		{ID: 1, Rank: 1},
+		{ID: 2, Rank: 2},
+		{ID: 3, Rank: 2},
+		{ID: 4, Rank: 4},
+	}
+
+	tests := []struct {
+		name     string
+		beforeId int64
+		afterId  int64
+		want     float64
+		wantOk   bool
+		wantErr  error
+	}{
+		{name: "between neighbours", beforeId: 1, afterId: 2, want: 1.5, wantOk: true},
+		{name: "after a card", beforeId: 3, want: 3, wantOk: true},
+		{name: "before a card", afterId: 2, want: 1.5, wantOk: true},
+		{name: "to the start", afterId: 1, want: 0, wantOk: true},
+		{name: "to the end", beforeId: 4, want: 5, wantOk: true},
+		{name: "tied neighbours need renumbering", beforeId: 2, afterId: 3, want: 2},
+		{name: "neighbours out of order", beforeId: 4, afterId: 1, wantErr: ErrInvalidMove},
+		{name: "neighbours not next to each other", beforeId: 1, afterId: 3, wantErr: ErrInvalidMove},
+		{name: "unknown card", beforeId: 9, wantErr: ErrInvalidMove},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := rankBetween(ranks, tt.beforeId, tt.afterId)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("rankBetween() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("rankBetween() unexpected error: %v", err)
+			}
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("rankBetween() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestCreatesDependencyCycle(t *testing.T) {
+	// 2 is blocked by 1, 3 by 2, and 4 by 3 and 1.
+	dependencies := []database.CardDependency{
+		{Cardid: 2, Blockedbyid: 1},
+		{Cardid: 3, Blockedbyid: 2},
+		{Cardid: 4, Blockedbyid: 3},
+		{Cardid: 4, Blockedbyid: 1},
+	}
+
+	tests := []struct {
+		name        string
+		cardId      int64
+		blockedById int64
+		want        bool
+	}{
+		{name: "independent cards", cardId: 5, blockedById: 1, want: false},
+		{name: "parallel path", cardId: 4, blockedById: 2, want: false},
+		{name: "direct cycle", cardId: 1, blockedById: 2, want: true},
+		{name: "indirect cycle", cardId: 1, blockedById: 4, want: true},
+		{name: "self dependency", cardId: 3, blockedById: 3, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := createsDependencyCycle(dependencies, tt.cardId, tt.blockedById); got != tt.want {
+				t.Errorf("createsDependencyCycle(%d, %d) = %v, want %v", tt.cardId, tt.blockedById, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeLevelUps(t *testing.T) {
+	first := &events.LevelUpEvent{PreviousLevel: 2, NewLevel: 3, TotalExp: 300}
+	second := &events.LevelUpEvent{PreviousLevel: 3, NewLevel: 4, TotalExp: 420}
+
+	if got := mergeLevelUps(nil, nil); got != nil {
+		t.Errorf("mergeLevelUps(nil, nil) = %+v, want nil", got)
+	}
+	if got := mergeLevelUps(first, nil); got != first {
+		t.Errorf("mergeLevelUps(first, nil) = %+v, want %+v", got, first)
+	}
+
+	got := mergeLevelUps(mergeLevelUps(nil, first), second)
+	if got.PreviousLevel != 2 || got.NewLevel != 4 || got.TotalExp != 420 {
+		t.Errorf("mergeLevelUps() = %+v, want levels 2 to 4 with 420 EXP", got)
+	}
+	if second.PreviousLevel != 3 {
+		t.Errorf("mergeLevelUps() modified its argument: %+v", second)
+	}
+}
@@ -3,4 +3,7 @@ package service
 const (
 	// DefaultUserID is the user ID for the single-user-mode application.
 	DefaultUserID int64 = 1
+
+	// DefaultProjectID is the "Inbox" project seeded by the initial migration.
+	DefaultProjectID int64 = 1
 )
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/sriram15/progressor-todo-app/internal/connection"
+	"github.com/sriram15/progressor-todo-app/internal/database"
+	"github.com/sriram15/progressor-todo-app/internal/events"
+)
+
+const (
+	dueReminderInterval = time.Minute
+
+	defaultDueReminderLead = 30 * time.Minute
+)
+
+type IDueReminderService interface {
+	Start()
+	Stop()
+}
+
+// DueReminderService publishes a CardDueSoonEvent when an open card comes within the
+// due_reminder_lead setting of its due date. Each due date is reminded once; cards that were
+// already overdue when the app started are left to the overdue view.
+type DueReminderService struct {
+	ctx            context.Context
+	dbManager      *connection.DBManager
+	settingService ISettingService
+	eventBus       *events.EventBus
+
+	mu   sync.Mutex
+	stop chan struct{}
+}
+
+func NewDueReminderService(dbManager *connection.DBManager, settingService ISettingService, eventBus *events.EventBus) *DueReminderService {
+	return &DueReminderService{
+		ctx:            context.Background(),
+		dbManager:      dbManager,
+		settingService: settingService,
+		eventBus:       eventBus,
+	}
+}
+
+// Start checks for cards due soon now and then every dueReminderInterval until Stop is called.
+func (s *DueReminderService) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stop != nil {
+		return
+	}
+	s.stop = make(chan struct{})
+
+	go func(stop chan struct{}) {
+		s.remind()
+		ticker := time.NewTicker(dueReminderInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.remind()
+			case <-stop:
+				return
+			}
+		}
+	}(s.stop)
+}
+
+func (s *DueReminderService) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stop == nil {
+		return
+	}
+	close(s.stop)
+	s.stop = nil
+}
+
+func (s *DueReminderService) remind() {
+	lead, err := s.settingService.GetDurationSetting("due_reminder_lead")
+	if err != nil {
+		log.Printf("Error getting due reminder lead setting: %v", err)
+		lead = defaultDueReminderLead
+	}
+
+	now := time.Now().UTC()
+	var reminders []events.CardDueSoonEvent
+	err = s.dbManager.Execute(s.ctx, func(q *database.Queries) error {
+		cards, err := q.ListCardsDueForReminder(s.ctx, database.ListCardsDueForReminderParams{
+			Status:      int64(Todo),
+			Now:         sql.NullTime{Valid: true, Time: now},
+			RemindUntil: sql.NullTime{Valid: true, Time: now.Add(lead)},
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, card := range cards {
+			err := q.MarkCardDueReminderSent(s.ctx, database.MarkCardDueReminderSentParams{
+				DueReminderSentAt: sql.NullTime{Valid: true, Time: now},
+				ID:                card.ID,
+			})
+			if err != nil {
+				return err
+			}
+			reminders = append(reminders, events.CardDueSoonEvent{
+				CardID:     card.ID,
+				ProjectID:  card.Projectid,
+				Title:      card.Title,
+				DueAt:      card.DueAt.Time,
+				RemindedAt: now,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error checking for cards due soon: %v", err)
+		return
+	}
+
+	// Events are published after the commit so a failed transaction is not reported.
+	for _, reminder := range reminders {
+		s.eventBus.Publish(events.CardDueSoonTopic, reminder)
+		log.Printf("Published CardDueSoonEvent: %+v", reminder)
+	}
+}
@@ -1,45 +1,116 @@
 package service
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"log"
 	"runtime"
-	"strconv"
 	"sync"
 	"time"
 
+	"github.com/sriram15/progressor-todo-app/internal/connection"
+	"github.com/sriram15/progressor-todo-app/internal/database"
 	"github.com/sriram15/progressor-todo-app/internal/events"
 	"github.com/wailsapp/wails/v3/pkg/application"
 	"github.com/wailsapp/wails/v3/pkg/services/notifications"
 )
 
+const (
+	defaultActiveCardTimeout  = 30 * time.Minute
+	defaultFocusExtension     = 5 * time.Minute
+	defaultPomodoroWork       = 25 * time.Minute
+	defaultPomodoroShortBreak = 5 * time.Minute
+	defaultPomodoroLongBreak  = 15 * time.Minute
+	defaultPomodoroCycles     = 4
+
+	focusTimerCategoryID = "active-card-timer-complete"
+)
+
+// Outcomes of a recorded focus session. A phase that ran for its planned length is completed,
+// whichever way it ended.
+const (
+	FocusOutcomeCompleted   = "completed"
+	FocusOutcomeStopped     = "stopped"
+	FocusOutcomeSkipped     = "skipped"
+	FocusOutcomeInterrupted = "interrupted"
+)
+
 // IFocusTimerService defines the interface for the focus timer service.
 type IFocusTimerService interface {
 	RegisterEventHandlers()
 	Shutdown()
 	ResumeTimer()
 	StopAndDeactivate()
+	ExtendSession()
+	GetFocusState() FocusState
+	Restore()
+	GetFocusSessions(start time.Time, end time.Time) ([]database.FocusSession, error)
 }
 
-// FocusTimerService manages the focus timer for active cards.
+// FocusState is a snapshot of the focus timer for the frontend.
+type FocusState struct {
+	CardID          int64  `json:"cardId"`
+	ProjectID       int64  `json:"projectId"`
+	Phase           string `json:"phase"`
+	PomodoroEnabled bool   `json:"pomodoroEnabled"`
+	// Cycle is the number of work sessions completed since the last long break.
+	Cycle                 int       `json:"cycle"`
+	CyclesBeforeLongBreak int       `json:"cyclesBeforeLongBreak"`
+	StartedAt             time.Time `json:"startedAt"`
+	EndsAt                time.Time `json:"endsAt"`
+	Paused                bool      `json:"paused"`
+}
+
+// FocusTimerService manages the focus timer for active cards. Without Pomodoro mode a single
+// timeout fires while a card is tracked. In Pomodoro mode work sessions alternate with breaks,
+// and the card's tracking is paused for the length of each break. Pausing the card during a
+// work phase suspends the timer until it is resumed. Every phase is recorded as a focus
+// session, so a running phase can be restored after a restart.
 type FocusTimerService struct {
-	app 		  *application.App
+	ctx            context.Context
+	dbManager      *connection.DBManager
+	app            *application.App
 	eventBus       *events.EventBus
 	cardService    ICardService
 	settingService ISettingService
 
-	timer        *time.Timer
-	startTime    time.Time
-	activeCardID int64
-	mu           sync.Mutex
+	timer *time.Timer
+	// timerSeq identifies the current timer, so callbacks of replaced timers are ignored.
+	timerSeq uint64
+	// startTime is moved forward by the length of every pause, so that time.Since(startTime)
+	// is the time worked in the phase.
+	startTime     time.Time
+	focusDuration time.Duration
+	// extended is set once the current phase was extended; its length no longer follows the settings.
+	extended        bool
+	activeCardID    int64
+	activeProjectID int64
+	phase           string
+	completedCycles int
+	// nextWorkDuration overrides the length of the work phase that follows a skipped break.
+	nextWorkDuration time.Duration
+	// sessionID is the FocusSessions row of the current phase, 0 when none is recorded.
+	sessionID int64
+	// paused is set while the focused card is paused during a work phase.
+	paused   bool
+	pausedAt time.Time
+	// closed is set on shutdown; the service then ignores further events.
+	closed bool
+	mu     sync.Mutex
 }
 
 // NewFocusTimerService creates a new FocusTimerService.
-func NewFocusTimerService(cs ICardService, ss ISettingService, bus *events.EventBus, app *application.App) *FocusTimerService {
+func NewFocusTimerService(cs ICardService, ss ISettingService, dbManager *connection.DBManager, bus *events.EventBus, app *application.App) *FocusTimerService {
 	return &FocusTimerService{
+		ctx:            context.Background(),
+		dbManager:      dbManager,
 		cardService:    cs,
 		settingService: ss,
 		eventBus:       bus,
-		app: 		  app,
+		app:            app,
+		phase:          events.FocusPhaseIdle,
 	}
 }
 
@@ -73,13 +144,76 @@ func (s *FocusTimerService) validateNotification() bool {
 	return true
 }
 
+// sendNotification sends a plain notification if notifications are authorized.
+func (s *FocusTimerService) sendNotification(id, title, body string) {
+	if !s.validateNotification() {
+		log.Println("Notification not authorized, skipping send.")
+		return
+	}
+
+	err := notifications.New().SendNotification(notifications.NotificationOptions{
+		ID:    id,
+		Title: title,
+		Body:  body,
+	})
+	if err != nil {
+		log.Println("Error sending notification:", err)
+	}
+}
+
+// sendActionNotification sends a notification offering to continue the session or to stop
+// tracking the card.
+func (s *FocusTimerService) sendActionNotification(id, title, body, continueTitle string) {
+	if !s.validateNotification() {
+		log.Println("Notification not authorized, skipping send.")
+		return
+	}
+
+	category := notifications.NotificationCategory{
+		ID: focusTimerCategoryID,
+		Actions: []notifications.NotificationAction{
+			{ID: "CONTINUE", Title: continueTitle},
+			{ID: "STOP", Title: "Stop"},
+		},
+	}
+
+	notification := notifications.New()
+	if err := notification.RegisterNotificationCategory(category); err != nil {
+		log.Println("Error registering notification category:", err)
+	}
+
+	notification.OnNotificationResponse(func(result notifications.NotificationResult) {
+		switch result.Response.ActionIdentifier {
+		case "CONTINUE":
+			s.ExtendSession()
+		case "STOP":
+			s.StopAndDeactivate()
+		}
+	})
+
+	err := notification.SendNotificationWithActions(notifications.NotificationOptions{
+		ID:         id,
+		Title:      title,
+		Body:       body,
+		CategoryID: focusTimerCategoryID,
+	})
+	if err != nil {
+		log.Println("Error sending notification:", err)
+	}
+}
+
 // RegisterEventHandlers subscribes the service to necessary events.
 func (s *FocusTimerService) RegisterEventHandlers() {
 	s.eventBus.Subscribe(events.CardStartedTopic, s.handleCardStarted)
 	s.eventBus.Subscribe(events.CardStoppedTopic, s.handleCardStopped)
+	s.eventBus.Subscribe(events.CardPausedTopic, s.handleCardPaused)
+	s.eventBus.Subscribe(events.CardResumedTopic, s.handleCardResumed)
+	s.eventBus.Subscribe(events.SettingChangedTopic, s.handleSettingChanged)
 }
 
-// handleCardStarted is the event handler for when a card is started.
+// handleCardStarted is the event handler for when a card is started. Starting the card whose
+// break is running, which happens when the card was stopped while the app was closed, ends the
+// break; any other card starts a new Pomodoro set.
 func (s *FocusTimerService) handleCardStarted(eventData interface{}) {
 	event, ok := eventData.(events.CardStartedEvent)
 	if !ok {
@@ -88,22 +222,34 @@ func (s *FocusTimerService) handleCardStarted(eventData interface{}) {
 	}
 	log.Printf("Received CardStartedEvent: %+v", event)
 
-	if s.validateNotification() {
-		notification := notifications.New()
-		notificationOption := notifications.NotificationOptions{
-			ID: "focus-timer-started",
-			Title: "Focus Timer Started",
-		}
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
 
-		err := notification.SendNotification(notificationOption);
-		if err != nil {
-			log.Println("Error sending notification:", err)
-		}
-	} else {
-		log.Println("Notification not authorized, skipping send.")
+	// Restore starts the card of a restored work session again, which continues that session.
+	if event.CardID == s.activeCardID && s.phase == events.FocusPhaseWork {
+		s.mu.Unlock()
+		return
+	}
+
+	if event.CardID == s.activeCardID && isBreakPhase(s.phase) {
+		s.endBreak()
+		s.mu.Unlock()
+		s.sendNotification("focus-timer-started", "Break Over", "Back to work.")
+		return
 	}
 
-	s.startTimer(event.CardID)
+	s.completedCycles = 0
+	s.nextWorkDuration = 0
+	s.activeCardID = event.CardID
+	s.activeProjectID = event.ProjectID
+	s.enterPhase(events.FocusPhaseWork, s.phaseDuration(events.FocusPhaseWork), FocusOutcomeStopped)
+	s.mu.Unlock()
+
+	s.sendNotification("focus-timer-started", "Focus Timer Started", "")
+	log.Println("Focus timer started for card:", event.CardID)
 }
 
 // handleCardStopped is the event handler for when a card is stopped.
@@ -114,134 +260,630 @@ func (s *FocusTimerService) handleCardStopped(eventData interface{}) {
 		return
 	}
 	log.Printf("Received CardStoppedEvent: %+v", event)
-	s.stopTimer()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// A stopped event for a card other than the focused one can arrive after the next card was started.
+	if s.closed || event.CardID != s.activeCardID {
+		return
+	}
+	s.enterIdle(FocusOutcomeStopped)
 }
 
-// startTimer starts the focus timer for a given card.
-func (s *FocusTimerService) startTimer(cardID int64) {
+// handleCardPaused suspends the timer when the focused card is paused during a work phase.
+// Cards are paused by this service when a break starts, which leaves the break running.
+func (s *FocusTimerService) handleCardPaused(eventData interface{}) {
+	event, ok := eventData.(events.CardPausedEvent)
+	if !ok {
+		log.Printf("Error: received non-CardPausedEvent for topic %s", events.CardPausedTopic)
+		return
+	}
+	log.Printf("Received CardPausedEvent: %+v", event)
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if s.closed || event.CardID != s.activeCardID || s.phase != events.FocusPhaseWork || s.paused {
+		return
+	}
+
 	if s.timer != nil {
 		s.timer.Stop()
 	}
+	s.paused = true
+	s.pausedAt = time.Now()
+	s.publishPhase(s.phase)
+	log.Printf("Focus timer for card %d paused, %s remaining", s.activeCardID, s.remaining())
+}
 
-	s.activeCardID = cardID
-	s.startTime = time.Now()
+// handleCardResumed continues a suspended work phase. Resuming the card during its break ends
+// the break and continues the Pomodoro set.
+func (s *FocusTimerService) handleCardResumed(eventData interface{}) {
+	event, ok := eventData.(events.CardResumedEvent)
+	if !ok {
+		log.Printf("Error: received non-CardResumedEvent for topic %s", events.CardResumedTopic)
+		return
+	}
+	log.Printf("Received CardResumedEvent: %+v", event)
 
-	var activeCardTimeout int = 30
-	activeCardSettingStr, err := s.settingService.GetSetting("active_card_timeout")
-	if err != nil {
-		log.Printf("Error getting active card timeout setting: %v", err)
-	} else {
-		activeCardTimeout, _ = strconv.Atoi(activeCardSettingStr)
-	}
-
-	focusDuration := time.Duration(activeCardTimeout) * time.Minute
-	s.timer = time.AfterFunc(focusDuration, func() {
-		s.mu.Lock()
-		defer s.mu.Unlock()
-		log.Println("Focus timer completed for card:", s.activeCardID)
-
-		if s.validateNotification() {
-			notificationCategoryId := "active-card-timer-complete"
-			category := notifications.NotificationCategory{
-				ID: notificationCategoryId,
-				Actions: []notifications.NotificationAction{
-					{
-            ID:    "CONTINUE",
-            Title: "Continue",
-        },{
-					ID:    "STOP",
-					Title: "Stop",
-				},
-				},
-			}
-
-			notification := notifications.New()
-			notification.RegisterNotificationCategory(category)
-
-			notification.OnNotificationResponse(func(result notifications.NotificationResult) {
-   	 			response := result.Response
-				if response.ActionIdentifier == "STOP" {
-					s.StopAndDeactivate()
-				}
-
-			})
-
-			notificationOption := notifications.NotificationOptions{
-				ID:    "focus-timer-completed",
-				Title: "Focus Timer Completed",
-				CategoryID: notificationCategoryId,
-			}
-
-			err := notification.SendNotificationWithActions(notificationOption)
-			if err != nil {
-				log.Println("Error sending notification:", err)
-			}
-		} else {
-			log.Println("Notification not authorized, skipping send.")
+	s.mu.Lock()
+	if s.closed || event.CardID != s.activeCardID {
+		s.mu.Unlock()
+		return
+	}
+
+	if s.phase == events.FocusPhaseWork {
+		if s.paused {
+			s.startTime = s.startTime.Add(time.Since(s.pausedAt))
+			s.paused = false
+			s.schedule(s.remaining())
+			s.publishPhase(s.phase)
+			log.Printf("Focus timer for card %d resumed, %s remaining", s.activeCardID, s.remaining())
 		}
+		s.mu.Unlock()
+		return
+	}
+	if !isBreakPhase(s.phase) {
+		s.mu.Unlock()
+		return
+	}
 
-		s.app.Event.Emit("active_card_timer_complete", s.activeCardID)
-	})
+	s.endBreak()
+	s.mu.Unlock()
+	s.sendNotification("focus-timer-started", "Break Over", "Back to work.")
+}
 
-	log.Println("Focus timer started for card:", cardID)
+// endBreak moves from a break into the next work phase of the same Pomodoro set.
+func (s *FocusTimerService) endBreak() {
+	duration, extended := s.nextWorkDuration, s.nextWorkDuration > 0
+	if !extended {
+		duration = s.phaseDuration(events.FocusPhaseWork)
+	}
+	s.nextWorkDuration = 0
+	s.enterPhase(events.FocusPhaseWork, duration, FocusOutcomeSkipped)
+	s.extended = extended
+	log.Println("Focus timer resumed after a break for card:", s.activeCardID)
 }
 
-// stopTimer stops the focus timer.
-func (s *FocusTimerService) stopTimer() {
+// handleSettingChanged reschedules a running phase when a setting that determines its length
+// changes. Time already spent in the phase counts towards the new length.
+func (s *FocusTimerService) handleSettingChanged(eventData interface{}) {
+	event, ok := eventData.(events.SettingChangedEvent)
+	if !ok {
+		log.Printf("Error: received non-SettingChangedEvent for topic %s", events.SettingChangedTopic)
+		return
+	}
+	switch event.Key {
+	case "active_card_timeout", "pomodoro_enabled", "pomodoro_work_duration",
+		"pomodoro_short_break_duration", "pomodoro_long_break_duration":
+	default:
+		return
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if s.closed || s.timer == nil || s.phase == events.FocusPhaseIdle || s.extended {
+		return
+	}
+
+	duration := s.phaseDuration(s.phase)
+	if duration == s.focusDuration {
+		return
+	}
+
+	if s.paused {
+		s.focusDuration = duration
+		s.updateSessionPlan()
+		s.publishPhase(s.phase)
+		return
+	}
+
+	// A timer that already fired has notified the user; it is not fired again.
+	if s.timer.Stop() {
+		s.focusDuration = duration
+		remaining := s.remaining()
+		s.timer.Reset(remaining)
+		s.updateSessionPlan()
+		s.publishPhase(s.phase)
+		log.Printf("Focus timer for card %d rescheduled, %s remaining", s.activeCardID, remaining)
+	}
+}
+
+// onTimerFired moves the timer on when the current phase ends.
+func (s *FocusTimerService) onTimerFired(seq uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed || seq != s.timerSeq {
+		return
+	}
+
+	switch s.phase {
+	case events.FocusPhaseWork:
+		s.completeWorkPhase()
+	case events.FocusPhaseShortBreak, events.FocusPhaseLongBreak:
+		s.completeBreakPhase()
+	}
+}
+
+func (s *FocusTimerService) completeWorkPhase() {
+	log.Println("Focus timer completed for card:", s.activeCardID)
+
+	if !s.pomodoroEnabled() {
+		s.sendActionNotification("focus-timer-completed", "Focus Timer Completed", "", "Continue")
+		s.app.Event.Emit("active_card_timer_complete", s.activeCardID)
+		return
+	}
+
+	s.completedCycles++
+	breakPhase := events.FocusPhaseShortBreak
+	if s.completedCycles >= s.cyclesBeforeLongBreak() {
+		breakPhase = events.FocusPhaseLongBreak
+	}
+
+	// The phase changes before the card is paused so that the paused event is recognised as a break.
+	breakDuration := s.phaseDuration(breakPhase)
+	s.enterPhase(breakPhase, breakDuration, FocusOutcomeCompleted)
+
+	if err := s.cardService.PauseCard(uint(s.activeProjectID), uint(s.activeCardID)); err != nil {
+		log.Printf("Error pausing card %d for a break: %v", s.activeCardID, err)
+		s.enterIdle(FocusOutcomeStopped)
+		return
+	}
+
+	body := fmt.Sprintf("Work session %d complete. Take a %s break.", s.completedCycles, breakDuration)
+	s.sendActionNotification("focus-timer-break", "Time For A Break", body, "Keep Working")
+}
+
+func (s *FocusTimerService) completeBreakPhase() {
+	log.Println("Break completed for card:", s.activeCardID)
+
+	if s.phase == events.FocusPhaseLongBreak {
+		s.completedCycles = 0
+	}
+	s.resumeAfterBreak()
+}
+
+// resumeAfterBreak resumes tracking of the focused card, or starts it again when it was stopped
+// while the app was closed. The resulting event moves the timer into the next work phase.
+func (s *FocusTimerService) resumeAfterBreak() {
+	card, err := s.cardService.GetCardById(uint(s.activeProjectID), uint(s.activeCardID))
+	if err != nil || card.Status == int64(Done) {
+		log.Printf("Card %d can no longer be resumed after the break: %v", s.activeCardID, err)
+		s.enterIdle(FocusOutcomeStopped)
+		return
+	}
+
+	resume := s.cardService.ResumeCard
+	if !card.Isactive {
+		resume = s.cardService.StartCard
+	}
+	if err := resume(uint(s.activeProjectID), uint(s.activeCardID)); err != nil {
+		log.Printf("Error resuming card %d after a break: %v", s.activeCardID, err)
+		s.enterIdle(FocusOutcomeStopped)
+	}
+}
+
+// enterPhase starts a phase of the given length and announces it. The session of the previous
+// phase is ended with previousOutcome unless it ran for its planned length.
+func (s *FocusTimerService) enterPhase(phase string, duration time.Duration, previousOutcome string) {
+	s.endSession(previousOutcome)
+
+	previous := s.phase
+	s.phase = phase
+	s.startTime = time.Now()
+	s.focusDuration = duration
+	s.extended = false
+	s.paused = false
+	s.schedule(duration)
+	s.beginSession()
+	s.publishPhase(previous)
+}
+
+// enterIdle stops the timer and forgets the focused card.
+func (s *FocusTimerService) enterIdle(outcome string) {
+	s.endSession(outcome)
+
 	if s.timer != nil {
 		s.timer.Stop()
 		s.timer = nil
 		log.Println("Focus timer stopped for card:", s.activeCardID)
-		s.activeCardID = 0
 	}
+
+	previous := s.phase
+	s.phase = events.FocusPhaseIdle
+	s.completedCycles = 0
+	s.nextWorkDuration = 0
+	s.extended = false
+	s.paused = false
+	if previous != events.FocusPhaseIdle {
+		s.publishPhase(previous)
+	}
+	s.activeCardID = 0
+	s.activeProjectID = 0
+}
+
+func (s *FocusTimerService) schedule(duration time.Duration) {
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.timerSeq++
+	seq := s.timerSeq
+	s.timer = time.AfterFunc(duration, func() {
+		s.onTimerFired(seq)
+	})
+}
+
+// publishPhase announces the current phase on the event bus and to the frontend.
+func (s *FocusTimerService) publishPhase(previous string) {
+	event := events.FocusPhaseChangedEvent{
+		CardID:        s.activeCardID,
+		ProjectID:     s.activeProjectID,
+		PreviousPhase: previous,
+		Phase:         s.phase,
+		Cycle:         s.completedCycles,
+		StartedAt:     s.startTime,
+		Paused:        s.paused,
+	}
+	if s.phase != events.FocusPhaseIdle && !s.paused {
+		event.EndsAt = s.startTime.Add(s.focusDuration)
+	}
+
+	s.eventBus.Publish(events.FocusPhaseChangedTopic, event)
+	s.app.Event.Emit(events.FocusPhaseChangedTopic, event)
 }
 
-// Shutdown is called on application exit to gracefully handle any active timer.
+// Shutdown is called on application exit and when switching profiles. The running session is
+// left open in the database so that Restore can pick it up again.
 func (s *FocusTimerService) Shutdown() {
-	s.stopTimer()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
 }
 
-// ResumeTimer resumes the timer after a user confirmation.
+// ResumeTimer restarts the current work phase after a user confirmation.
 func (s *FocusTimerService) ResumeTimer() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.resumeTimerInternal()
+
+	if s.activeCardID != 0 && s.phase == events.FocusPhaseWork && !s.paused {
+		log.Println("Resuming timer for card:", s.activeCardID)
+		s.enterPhase(events.FocusPhaseWork, s.phaseDuration(events.FocusPhaseWork), FocusOutcomeStopped)
+	}
 }
 
-func (s *FocusTimerService) resumeTimerInternal() {
-	cardID := s.activeCardID
-	if cardID != 0 {
-		log.Println("Resuming timer for card:", cardID)
-		// Drop the lock to avoid deadlock with startTimer
-		s.mu.Unlock()
-		s.startTimer(cardID)
-		s.mu.Lock()
+// ExtendSession keeps the user working for the configured extension. During a break the break
+// is skipped, tracking resumes and the finished work session continues.
+func (s *FocusTimerService) ExtendSession() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.activeCardID == 0 {
+		return
+	}
+	extension := s.durationSetting("focus_extend_duration", defaultFocusExtension)
+
+	switch s.phase {
+	case events.FocusPhaseWork:
+		s.focusDuration = max(s.focusDuration, s.elapsed()) + extension
+		s.extended = true
+		if !s.paused {
+			s.schedule(s.remaining())
+		}
+		s.updateSessionPlan()
+		s.publishPhase(s.phase)
+		log.Printf("Focus session for card %d extended by %s", s.activeCardID, extension)
+
+	case events.FocusPhaseShortBreak, events.FocusPhaseLongBreak:
+		s.completedCycles = max(s.completedCycles-1, 0)
+		s.nextWorkDuration = extension
+		if s.timer != nil {
+			s.timer.Stop()
+		}
+		s.resumeAfterBreak()
 	}
 }
 
 // StopAndDeactivate stops the timer and deactivates the card.
 func (s *FocusTimerService) StopAndDeactivate() {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.stopAndDeactivateInternal()
-}
+	cardID, projectID := s.activeCardID, s.activeProjectID
+	s.enterIdle(FocusOutcomeStopped)
+	s.mu.Unlock()
 
-func (s *FocusTimerService) stopAndDeactivateInternal() {
-	cardID := s.activeCardID
+	// During a break the card is paused, and stopping it ends its time entry at the pause.
 	if cardID != 0 {
-		// Drop the lock to avoid deadlock with stopTimer
-		s.mu.Unlock()
-		s.stopTimer()
-		s.mu.Lock()
-		// Assuming project ID is 1, this might need to be retrieved differently
-		if err := s.cardService.StopCard(1, uint(cardID)); err != nil {
+		if err := s.cardService.StopCard(uint(projectID), uint(cardID)); err != nil {
 			log.Printf("Error deactivating card %d: %v", cardID, err)
 		}
 	}
 }
+
+// GetFocusState returns the current phase of the focus timer.
+func (s *FocusTimerService) GetFocusState() FocusState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := FocusState{
+		CardID:                s.activeCardID,
+		ProjectID:             s.activeProjectID,
+		Phase:                 s.phase,
+		PomodoroEnabled:       s.pomodoroEnabled(),
+		Cycle:                 s.completedCycles,
+		CyclesBeforeLongBreak: s.cyclesBeforeLongBreak(),
+		Paused:                s.paused,
+	}
+	if s.phase != events.FocusPhaseIdle {
+		state.StartedAt = s.startTime
+		if !s.paused {
+			state.EndsAt = s.startTime.Add(s.focusDuration)
+		}
+	}
+	return state
+}
+
+// Restore resumes the session that was running when the app last closed or the profile was
+// switched away. The card of a work session is usually no longer tracked, as it is stopped on
+// exit or its entry is closed after a crash; tracking is then started again and the session
+// continues with the time that was left when the card stopped. A break is only resumed while it
+// has not ended yet. Any other session is recorded as interrupted. Time the card spent paused
+// does not count towards a work session.
+func (s *FocusTimerService) Restore() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queries := s.dbManager.Queries(s.ctx)
+	session, err := queries.GetRunningFocusSession(s.ctx)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("Error getting running focus session: %v", err)
+		}
+		return
+	}
+
+	planned := time.Duration(session.Plannedseconds) * time.Second
+	startTime := session.Startedat
+	paused, pausedAt := false, time.Time{}
+
+	restorable, restart := false, false
+	switch session.Phase {
+	case events.FocusPhaseWork:
+		card, err := s.cardService.GetCardById(uint(session.Projectid), uint(session.Cardid))
+		if err != nil || card.Status == int64(Done) {
+			break
+		}
+		if !card.Isactive {
+			worked, ok := s.workedBeforeStop(queries, session)
+			restorable, restart = ok, ok
+			startTime = time.Now().Add(-worked)
+			break
+		}
+
+		restorable = true
+		pauses, err := s.cardService.GetActivePauses(uint(session.Projectid), uint(session.Cardid))
+		if err != nil {
+			log.Printf("Error getting pauses of card %d: %v", session.Cardid, err)
+		}
+		activeUntil := time.Now()
+		if n := len(pauses); n > 0 && !pauses[n-1].Resumedat.Valid {
+			paused, pausedAt = true, pauses[n-1].Pausedat
+			activeUntil = pausedAt
+		}
+		startTime = startTime.Add(time.Duration(pausedSeconds(pauses, session.Startedat, activeUntil)) * time.Second)
+	case events.FocusPhaseShortBreak, events.FocusPhaseLongBreak:
+		restorable = planned > time.Since(startTime)
+	}
+
+	if !restorable {
+		s.sessionID = session.ID
+		s.endSession(FocusOutcomeInterrupted)
+		return
+	}
+
+	if err := queries.IncrementFocusSessionInterruptions(s.ctx, session.ID); err != nil {
+		log.Printf("Error recording focus session interruption: %v", err)
+	}
+
+	s.sessionID = session.ID
+	s.activeCardID = session.Cardid
+	s.activeProjectID = session.Projectid
+	s.phase = session.Phase
+	s.completedCycles = int(session.Cycle)
+	s.startTime = startTime
+	s.focusDuration = planned
+	s.extended = planned != s.phaseDuration(session.Phase)
+	s.paused, s.pausedAt = paused, pausedAt
+
+	// The started event is ignored by handleCardStarted, as the card is already focused.
+	if restart {
+		if err := s.cardService.StartCard(uint(session.Projectid), uint(session.Cardid)); err != nil {
+			log.Printf("Error restarting card %d for its focus session: %v", session.Cardid, err)
+			s.endSession(FocusOutcomeInterrupted)
+			s.activeCardID, s.activeProjectID, s.phase = 0, 0, events.FocusPhaseIdle
+			return
+		}
+	}
+	if !paused {
+		s.schedule(s.remaining())
+	}
+	s.publishPhase(events.FocusPhaseIdle)
+
+	log.Printf("Restored %s focus session for card %d, %s remaining", session.Phase, session.Cardid, s.remaining())
+}
+
+// workedBeforeStop returns how long the card of a work session was tracked between the start of
+// the session and the end of its last time entry, leaving out the time it was paused. It
+// reports false when the card was not tracked after the session started.
+func (s *FocusTimerService) workedBeforeStop(queries *database.Queries, session database.FocusSession) (time.Duration, bool) {
+	entries, err := queries.ListTimeEntriesByCard(s.ctx, session.Cardid)
+	if err != nil {
+		log.Printf("Error getting time entries of card %d: %v", session.Cardid, err)
+		return 0, false
+	}
+	if len(entries) == 0 {
+		return 0, false
+	}
+
+	entry := entries[len(entries)-1]
+	from := entry.Starttime
+	if session.Startedat.After(from) {
+		from = session.Startedat
+	}
+	if !entry.Endtime.After(from) {
+		return 0, false
+	}
+
+	pauses, err := queries.ListTimeEntryPauses(s.ctx, entry.ID)
+	if err != nil {
+		log.Printf("Error getting pauses of card %d: %v", session.Cardid, err)
+	}
+	paused := time.Duration(pausedSeconds(pauses, from, entry.Endtime)) * time.Second
+	return max(entry.Endtime.Sub(from)-paused, 0), true
+}
+
+// GetFocusSessions returns the sessions started within [start, end).
+func (s *FocusTimerService) GetFocusSessions(start time.Time, end time.Time) ([]database.FocusSession, error) {
+	if !end.After(start) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	queries := s.dbManager.Queries(s.ctx)
+	return queries.ListFocusSessionsInRange(s.ctx, database.ListFocusSessionsInRangeParams{
+		RangeStart: start.UTC(),
+		RangeEnd:   end.UTC(),
+	})
+}
+
+// beginSession records the current phase as a new focus session.
+func (s *FocusTimerService) beginSession() {
+	var id int64
+	err := s.dbManager.Execute(s.ctx, func(q *database.Queries) error {
+		var err error
+		id, err = q.CreateFocusSession(s.ctx, database.CreateFocusSessionParams{
+			Cardid:         s.activeCardID,
+			Projectid:      s.activeProjectID,
+			Phase:          s.phase,
+			Cycle:          int64(s.completedCycles),
+			Plannedseconds: int64(s.focusDuration.Seconds()),
+			Startedat:      s.startTime.UTC(),
+		})
+		return err
+	})
+	if err != nil {
+		log.Printf("Error recording focus session for card %d: %v", s.activeCardID, err)
+		return
+	}
+	s.sessionID = id
+}
+
+// endSession closes the recorded session of the current phase.
+func (s *FocusTimerService) endSession(earlyOutcome string) {
+	if s.sessionID == 0 {
+		return
+	}
+
+	outcome := earlyOutcome
+	if earlyOutcome != FocusOutcomeInterrupted && s.elapsed() >= s.focusDuration {
+		outcome = FocusOutcomeCompleted
+	}
+
+	err := s.dbManager.Execute(s.ctx, func(q *database.Queries) error {
+		return q.EndFocusSession(s.ctx, database.EndFocusSessionParams{
+			Endedat: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+			Outcome: sql.NullString{String: outcome, Valid: true},
+			ID:      s.sessionID,
+		})
+	})
+	if err != nil {
+		log.Printf("Error ending focus session %d: %v", s.sessionID, err)
+	}
+	s.sessionID = 0
+}
+
+// updateSessionPlan stores a changed length of the current phase.
+func (s *FocusTimerService) updateSessionPlan() {
+	if s.sessionID == 0 {
+		return
+	}
+
+	err := s.dbManager.Execute(s.ctx, func(q *database.Queries) error {
+		return q.UpdateFocusSessionPlan(s.ctx, database.UpdateFocusSessionPlanParams{
+			Plannedseconds: int64(s.focusDuration.Seconds()),
+			ID:             s.sessionID,
+		})
+	})
+	if err != nil {
+		log.Printf("Error updating focus session %d: %v", s.sessionID, err)
+	}
+}
+
+// phaseDuration returns the configured length of a phase.
+func (s *FocusTimerService) phaseDuration(phase string) time.Duration {
+	switch phase {
+	case events.FocusPhaseShortBreak:
+		return s.durationSetting("pomodoro_short_break_duration", defaultPomodoroShortBreak)
+	case events.FocusPhaseLongBreak:
+		return s.durationSetting("pomodoro_long_break_duration", defaultPomodoroLongBreak)
+	}
+
+	if s.pomodoroEnabled() {
+		return s.durationSetting("pomodoro_work_duration", defaultPomodoroWork)
+	}
+
+	timeout, err := s.settingService.GetIntSetting("active_card_timeout")
+	if err != nil {
+		log.Printf("Error getting active card timeout setting: %v", err)
+		return defaultActiveCardTimeout
+	}
+	return time.Duration(timeout) * time.Minute
+}
+
+func (s *FocusTimerService) pomodoroEnabled() bool {
+	enabled, err := s.settingService.GetBoolSetting("pomodoro_enabled")
+	if err != nil {
+		log.Printf("Error getting pomodoro setting: %v", err)
+		return false
+	}
+	return enabled
+}
+
+func (s *FocusTimerService) cyclesBeforeLongBreak() int {
+	cycles, err := s.settingService.GetIntSetting("pomodoro_cycles_before_long_break")
+	if err != nil {
+		log.Printf("Error getting pomodoro cycles setting: %v", err)
+		return defaultPomodoroCycles
+	}
+	return int(cycles)
+}
+
+func (s *FocusTimerService) durationSetting(key string, fallback time.Duration) time.Duration {
+	duration, err := s.settingService.GetDurationSetting(key)
+	if err != nil {
+		log.Printf("Error getting %s setting: %v", key, err)
+		return fallback
+	}
+	return duration
+}
+
+// elapsed is the time spent in the current phase, not counting pauses.
+func (s *FocusTimerService) elapsed() time.Duration {
+	if s.paused {
+		return s.pausedAt.Sub(s.startTime)
+	}
+	return time.Since(s.startTime)
+}
+
+func (s *FocusTimerService) remaining() time.Duration {
+	return max(s.focusDuration-s.elapsed(), 0)
+}
+
+func isBreakPhase(phase string) bool {
+	return phase == events.FocusPhaseShortBreak || phase == events.FocusPhaseLongBreak
+}
@@ -0,0 +1,294 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/sriram15/progressor-todo-app/internal/connection"
+	"github.com/sriram15/progressor-todo-app/internal/database"
+	"github.com/sriram15/progressor-todo-app/internal/events"
+)
+
+const (
+	heartbeatInterval = 30 * time.Second
+
+	defaultIdleGapThreshold = 5 * time.Minute
+)
+
+// Policies for time entries found running after a crash.
+const (
+	StaleEntryPolicyCloseAtLastSeen = "close_at_last_seen"
+	StaleEntryPolicyAsk             = "ask"
+)
+
+// Resolutions of a stale time entry.
+const (
+	StaleEntryResolutionPending  = "pending"
+	StaleEntryResolutionLastSeen = "last_seen"
+	StaleEntryResolutionKeep     = "keep"
+)
+
+var (
+	ErrStaleEntryNotFound          = errors.New("no unresolved stale time entry for this card")
+	ErrInvalidStaleEntryResolution = errors.New("invalid stale time entry resolution")
+)
+
+type IHeartbeatService interface {
+	Start()
+	Stop()
+	Reconcile() (*events.StaleTimeEntriesEvent, error)
+	GetLastReconciliation() *events.StaleTimeEntriesEvent
+	ResolveStaleEntry(cardID int64, resolution string) error
+}
+
+// HeartbeatService records that the app is running, so that time entries left running by a
+// crash can be detected and closed when the profile is next opened. While the app runs it also
+// watches for gaps between heartbeats, e.g. when the computer slept with a card tracked.
+type HeartbeatService struct {
+	ctx            context.Context
+	dbManager      *connection.DBManager
+	cardService    ICardService
+	settingService ISettingService
+	eventBus       *events.EventBus
+
+	mu         sync.Mutex
+	stop       chan struct{}
+	lastReport *events.StaleTimeEntriesEvent
+}
+
+func NewHeartbeatService(dbManager *connection.DBManager, cardService ICardService, settingService ISettingService, eventBus *events.EventBus) *HeartbeatService {
+	return &HeartbeatService{
+		ctx:            context.Background(),
+		dbManager:      dbManager,
+		cardService:    cardService,
+		settingService: settingService,
+		eventBus:       eventBus,
+	}
+}
+
+// Start records a heartbeat now and then every heartbeatInterval until Stop is called.
+func (s *HeartbeatService) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stop != nil {
+		return
+	}
+	s.stop = make(chan struct{})
+	s.beat(false)
+
+	go func(stop chan struct{}) {
+		ticker := time.NewTicker(heartbeatInterval)
+		defer ticker.Stop()
+		last := time.Now()
+		for {
+			select {
+			case <-ticker.C:
+				now := time.Now()
+				s.checkIdleGap(last, now)
+				last = now
+				s.beat(false)
+			case <-stop:
+				return
+			}
+		}
+	}(s.stop)
+}
+
+// Stop ends the heartbeat and records that the app stopped cleanly, so that cards left
+// running on purpose, e.g. when switching profiles, are not treated as stale.
+func (s *HeartbeatService) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stop == nil {
+		return
+	}
+	close(s.stop)
+	s.stop = nil
+	s.beat(true)
+}
+
+func (s *HeartbeatService) beat(stoppedCleanly bool) {
+	err := s.dbManager.Execute(s.ctx, func(q *database.Queries) error {
+		return q.UpsertHeartbeat(s.ctx, database.UpsertHeartbeatParams{
+			Lastseenat:     time.Now().UTC(),
+			Stoppedcleanly: stoppedCleanly,
+		})
+	})
+	if err != nil {
+		log.Printf("Error recording heartbeat: %v", err)
+	}
+}
+
+// checkIdleGap publishes an IdleDetectedEvent when the time between two heartbeats shows the
+// app was not running while a card was tracked. Sleep shows as wall-clock time passing while the
+// monotonic clock stood still, and a stalled app as heartbeats that are overdue. The gap runs
+// from the last heartbeat before it to the first one after it.
+func (s *HeartbeatService) checkIdleGap(last time.Time, now time.Time) {
+	wallGap := now.Round(0).Sub(last.Round(0))
+	idle := max(wallGap-now.Sub(last), wallGap-heartbeatInterval)
+
+	threshold, err := s.settingService.GetDurationSetting("idle_gap_threshold")
+	if err != nil {
+		log.Printf("Error getting idle gap threshold setting: %v", err)
+		threshold = defaultIdleGapThreshold
+	}
+	if idle < threshold {
+		return
+	}
+
+	queries := s.dbManager.Queries(s.ctx)
+	activeCard, err := queries.GetActiveCard(s.ctx)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("Error getting active card: %v", err)
+		}
+		return
+	}
+
+	entry, err := queries.GetActiveTimeEntry(s.ctx, activeCard.ID)
+	if err != nil {
+		log.Printf("Error getting active time entry of card %d: %v", activeCard.ID, err)
+		return
+	}
+
+	// Paused time is not tracked, so a gap while paused needs no resolution.
+	if _, err := queries.GetOpenTimeEntryPause(s.ctx, entry.ID); err == nil {
+		return
+	}
+
+	gapStart := last.Round(0).UTC()
+	if gapStart.Before(entry.Starttime) {
+		gapStart = entry.Starttime
+	}
+	gapEnd := now.Round(0).UTC()
+	if !gapEnd.After(gapStart) {
+		return
+	}
+
+	event := events.IdleDetectedEvent{
+		CardID:      activeCard.ID,
+		ProjectID:   activeCard.Projectid,
+		TimeEntryID: entry.ID,
+		GapStart:    gapStart,
+		GapEnd:      gapEnd,
+		DetectedAt:  time.Now().UTC(),
+	}
+	s.eventBus.Publish(events.IdleDetectedTopic, event)
+	log.Printf("Published IdleDetectedEvent: %+v", event)
+}
+
+// Reconcile looks for time entries that were still running when the app last stopped without
+// a clean shutdown. Depending on the stale_entry_policy setting they are closed at the last
+// heartbeat or left for the user to resolve. It must run before Start, and returns nil when
+// there was nothing to reconcile.
+func (s *HeartbeatService) Reconcile() (*events.StaleTimeEntriesEvent, error) {
+	queries := s.dbManager.Queries(s.ctx)
+
+	heartbeat, err := queries.GetLastHeartbeat(s.ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last heartbeat: %w", err)
+	}
+	if heartbeat.Stoppedcleanly {
+		return nil, nil
+	}
+
+	activeEntries, err := queries.ListActiveTimeEntries(s.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active time entries: %w", err)
+	}
+	if len(activeEntries) == 0 {
+		return nil, nil
+	}
+
+	policy, err := s.settingService.GetSetting("stale_entry_policy")
+	if err != nil {
+		log.Printf("Error getting stale entry policy setting: %v", err)
+		policy = StaleEntryPolicyCloseAtLastSeen
+	}
+
+	report := &events.StaleTimeEntriesEvent{
+		LastSeenAt: heartbeat.Lastseenat,
+		DetectedAt: time.Now().UTC(),
+	}
+	for _, entry := range activeEntries {
+		stale := events.StaleTimeEntry{
+			CardID:      entry.CardID,
+			ProjectID:   entry.Projectid,
+			TimeEntryID: entry.TimeEntryID,
+			CardTitle:   entry.Title,
+			StartedAt:   entry.Starttime,
+			Resolution:  StaleEntryResolutionPending,
+		}
+
+		if policy != StaleEntryPolicyAsk {
+			if err := s.closeAtLastSeen(&stale, heartbeat.Lastseenat); err != nil {
+				log.Printf("Error closing stale time entry %d: %v", entry.TimeEntryID, err)
+			}
+		}
+		report.Entries = append(report.Entries, stale)
+	}
+
+	log.Printf("Reconciled %d time entries left running since %s", len(report.Entries), heartbeat.Lastseenat)
+
+	s.mu.Lock()
+	s.lastReport = report
+	s.mu.Unlock()
+
+	s.eventBus.Publish(events.StaleTimeEntriesTopic, *report)
+	return report, nil
+}
+
+// GetLastReconciliation returns the result of the last Reconcile that found stale entries.
+func (s *HeartbeatService) GetLastReconciliation() *events.StaleTimeEntriesEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastReport
+}
+
+// ResolveStaleEntry applies the user's choice for an entry left pending by the ask policy:
+// close it at the last heartbeat, or keep it running and count the time in between.
+func (s *HeartbeatService) ResolveStaleEntry(cardID int64, resolution string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lastReport == nil {
+		return ErrStaleEntryNotFound
+	}
+
+	for i := range s.lastReport.Entries {
+		stale := &s.lastReport.Entries[i]
+		if stale.CardID != cardID || stale.Resolution != StaleEntryResolutionPending {
+			continue
+		}
+
+		switch resolution {
+		case StaleEntryResolutionLastSeen:
+			return s.closeAtLastSeen(stale, s.lastReport.LastSeenAt)
+		case StaleEntryResolutionKeep:
+			stale.Resolution = StaleEntryResolutionKeep
+			return nil
+		default:
+			return ErrInvalidStaleEntryResolution
+		}
+	}
+	return ErrStaleEntryNotFound
+}
+
+func (s *HeartbeatService) closeAtLastSeen(stale *events.StaleTimeEntry, lastSeenAt time.Time) error {
+	if err := s.cardService.StopCardAt(uint(stale.ProjectID), uint(stale.CardID), lastSeenAt); err != nil {
+		return err
+	}
+	stale.ClosedAt = lastSeenAt
+	stale.Resolution = StaleEntryResolutionLastSeen
+	return nil
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/sriram15/progressor-todo-app/internal/database"
+	"github.com/sriram15/progressor-todo-app/internal/events"
+)
+
+const (
+	LevelCurveLinear      = "linear"
+	LevelCurveQuadratic   = "quadratic"
+	LevelCurveExponential = "exponential"
+
+	defaultLevelCurveBase int64 = 100
+	// exponentialCurveGrowth is the factor by which each level gets harder on the exponential curve.
+	exponentialCurveGrowth = 1.5
+)
+
+// LevelCurve describes how much EXP each level costs.
+type LevelCurve struct {
+	Kind string
+	Base int64
+}
+
+// ExpToNextLevel returns the EXP needed to advance from level to level+1.
+func (c LevelCurve) ExpToNextLevel(level int64) int64 {
+	base := c.Base
+	if base <= 0 {
+		base = defaultLevelCurveBase
+	}
+
+	switch c.Kind {
+	case LevelCurveQuadratic:
+		return base * level * level
+	case LevelCurveExponential:
+		return int64(math.Round(float64(base) * math.Pow(exponentialCurveGrowth, float64(level-1))))
+	default:
+		return base * level
+	}
+}
+
+// LevelForExp returns the level reached with totalExp, how far into that level the user
+// is and the EXP that level requires in total.
+func (c LevelCurve) LevelForExp(totalExp int64) (level int64, expIntoLevel int64, expToNextLevel int64) {
+	level, expIntoLevel = 1, totalExp
+	for {
+		expToNextLevel = c.ExpToNextLevel(level)
+		if expIntoLevel < expToNextLevel {
+			return level, expIntoLevel, expToNextLevel
+		}
+		expIntoLevel -= expToNextLevel
+		level++
+	}
+}
+
+// ExpForLevel returns the total EXP at which level is reached.
+func (c LevelCurve) ExpForLevel(level int64) int64 {
+	var total int64
+	for l := int64(1); l < level; l++ {
+		total += c.ExpToNextLevel(l)
+	}
+	return total
+}
+
+// loadLevelCurve reads the curve from the settings, falling back to the defaults when the
+// settings cannot be read. Values are validated by the setting schema.
+func loadLevelCurve(settingService ISettingService) LevelCurve {
+	curve := LevelCurve{Kind: LevelCurveLinear, Base: defaultLevelCurveBase}
+
+	if kind, err := settingService.GetSetting("level_curve"); err == nil {
+		curve.Kind = kind
+	} else {
+		log.Printf("Error getting level curve setting: %v", err)
+	}
+
+	if base, err := settingService.GetIntSetting("level_curve_base"); err == nil {
+		curve.Base = base
+	} else {
+		log.Printf("Error getting level curve base setting: %v", err)
+	}
+
+	return curve
+}
+
+// applyExperience adds exp to the user's progression columns, designed to be used within the
+// transaction that records the EXP. The returned event is nil when the level did not change.
+func applyExperience(ctx context.Context, q *database.Queries, curve LevelCurve, userID int64, exp int64) (*events.LevelUpEvent, error) {
+	changed, err := changeExperience(ctx, q, curve, userID, exp)
+	if err != nil {
+		return nil, err
+	}
+
+	if changed.NewLevel <= changed.PreviousLevel {
+		return nil, nil
+	}
+	return &events.LevelUpEvent{
+		UserID:        userID,
+		PreviousLevel: changed.PreviousLevel,
+		NewLevel:      changed.NewLevel,
+		TotalExp:      changed.TotalExp,
+		ReachedAt:     changed.ChangedAt,
+	}, nil
+}
+
+// changeExperience adds exp, which may be negative, to the user's progression columns within
+// the caller's transaction. Every level gained is written to LevelHistory with the EXP it is
+// reached at and earns one progression point; every level lost removes its history row and
+// takes a point back. Passing 0 brings the level in line with a changed curve.
+func changeExperience(ctx context.Context, q *database.Queries, curve LevelCurve, userID int64, exp int64) (events.LevelChangedEvent, error) {
+	progression, err := q.GetUserProgression(ctx, userID)
+	if err != nil {
+		return events.LevelChangedEvent{}, fmt.Errorf("failed to get user progression: %w", err)
+	}
+
+	totalExp := max(progression.ArcherExperience+exp, 0)
+	newLevel, _, _ := curve.LevelForExp(totalExp)
+	points := progression.ProgressionPoints
+
+	for level := progression.ArcherLevel + 1; level <= newLevel; level++ {
+		err := q.CreateLevelHistory(ctx, database.CreateLevelHistoryParams{
+			Userid:   userID,
+			Level:    level,
+			Totalexp: curve.ExpForLevel(level),
+		})
+		if err != nil {
+			return events.LevelChangedEvent{}, fmt.Errorf("failed to record level history: %w", err)
+		}
+		points++
+	}
+
+	if newLevel < progression.ArcherLevel {
+		err := q.DeleteLevelHistoryAbove(ctx, database.DeleteLevelHistoryAboveParams{
+			Userid: userID,
+			Level:  newLevel,
+		})
+		if err != nil {
+			return events.LevelChangedEvent{}, fmt.Errorf("failed to remove level history: %w", err)
+		}
+		points = max(points-(progression.ArcherLevel-newLevel), 0)
+	}
+
+	err = q.UpdateUserProgression(ctx, database.UpdateUserProgressionParams{
+		ID:                userID,
+		ProgressionPoints: points,
+		ArcherLevel:       newLevel,
+		ArcherExperience:  totalExp,
+	})
+	if err != nil {
+		return events.LevelChangedEvent{}, fmt.Errorf("failed to update user progression: %w", err)
+	}
+
+	return events.LevelChangedEvent{
+		UserID:        userID,
+		PreviousLevel: progression.ArcherLevel,
+		NewLevel:      newLevel,
+		TotalExp:      totalExp,
+		ChangedAt:     time.Now().UTC(),
+	}, nil
+}
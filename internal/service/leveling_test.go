@@ -0,0 +1,64 @@
+package service
+
+import "testing"
+
+func TestLevelCurveExpToNextLevel(t *testing.T) {
+	tests := []struct {
+		name  string
+		curve LevelCurve
+		level int64
+		want  int64
+	}{
+		{name: "linear first level", curve: LevelCurve{Kind: LevelCurveLinear, Base: 100}, level: 1, want: 100},
+		{name: "linear", curve: LevelCurve{Kind: LevelCurveLinear, Base: 100}, level: 5, want: 500},
+		{name: "quadratic", curve: LevelCurve{Kind: LevelCurveQuadratic, Base: 50}, level: 3, want: 450},
+		{name: "exponential first level", curve: LevelCurve{Kind: LevelCurveExponential, Base: 100}, level: 1, want: 100},
+		{name: "exponential", curve: LevelCurve{Kind: LevelCurveExponential, Base: 100}, level: 3, want: 225},
+		{name: "exponential rounds", curve: LevelCurve{Kind: LevelCurveExponential, Base: 100}, level: 4, want: 338},
+		{name: "unknown kind is linear", curve: LevelCurve{Kind: "cubic", Base: 10}, level: 4, want: 40},
+		{name: "missing base uses default", curve: LevelCurve{Kind: LevelCurveLinear}, level: 2, want: 2 * defaultLevelCurveBase},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.curve.ExpToNextLevel(tt.level); got != tt.want {
+				t.Errorf("ExpToNextLevel(%d) = %d, want %d", tt.level, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLevelCurveLevelForExp(t *testing.T) {
+	linear := LevelCurve{Kind: LevelCurveLinear, Base: 100}
+	quadratic := LevelCurve{Kind: LevelCurveQuadratic, Base: 100}
+
+	tests := []struct {
+		name         string
+		curve        LevelCurve
+		totalExp     int64
+		wantLevel    int64
+		wantInto     int64
+		wantToNext   int64
+		wantExpLevel int64
+	}{
+		{name: "no exp", curve: linear, totalExp: 0, wantLevel: 1, wantInto: 0, wantToNext: 100, wantExpLevel: 0},
+		{name: "just below level 2", curve: linear, totalExp: 99, wantLevel: 1, wantInto: 99, wantToNext: 100, wantExpLevel: 0},
+		{name: "exactly level 2", curve: linear, totalExp: 100, wantLevel: 2, wantInto: 0, wantToNext: 200, wantExpLevel: 100},
+		{name: "into level 3", curve: linear, totalExp: 350, wantLevel: 3, wantInto: 50, wantToNext: 300, wantExpLevel: 300},
+		{name: "quadratic level 3", curve: quadratic, totalExp: 500, wantLevel: 3, wantInto: 0, wantToNext: 900, wantExpLevel: 500},
+		{name: "harder curve lowers the level", curve: quadratic, totalExp: 350, wantLevel: 2, wantInto: 250, wantToNext: 400, wantExpLevel: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, into, toNext := tt.curve.LevelForExp(tt.totalExp)
+			if level != tt.wantLevel || into != tt.wantInto || toNext != tt.wantToNext {
+				t.Errorf("LevelForExp(%d) = (%d, %d, %d), want (%d, %d, %d)",
+					tt.totalExp, level, into, toNext, tt.wantLevel, tt.wantInto, tt.wantToNext)
+			}
+			if got := tt.curve.ExpForLevel(level); got != tt.wantExpLevel {
+				t.Errorf("ExpForLevel(%d) = %d, want %d", level, got, tt.wantExpLevel)
+			}
+		})
+	}
+}
@@ -20,21 +20,35 @@ type GetStatsResult struct {
 	MonthProgress StatCardData `json:"monthProgress"`
 }
 
+// LevelInfo is the user's current level and how far they are towards the next one.
+type LevelInfo struct {
+	Level             int64   `json:"level"`
+	TotalExp          int64   `json:"totalExp"`
+	ExpIntoLevel      int64   `json:"expIntoLevel"`
+	ExpToNextLevel    int64   `json:"expToNextLevel"`
+	ProgressToNext    float64 `json:"progressToNext"`
+	ProgressionPoints int64   `json:"progressionPoints"`
+}
+
 type IProgressService interface {
 	GetStats() (GetStatsResult, error)
 	GetDailyTotalMinutes() ([]database.GetDailyTotalMinutesRow, error)
 	GetTotalExpForUser(userID int64) (float64, error)
+	GetLevelInfo(userID int64) (LevelInfo, error)
+	GetLevelHistory(userID int64) ([]database.LevelHistory, error)
 }
 
 type ProgressService struct {
-	ctx       context.Context
-	dbManager *connection.DBManager
+	ctx            context.Context
+	dbManager      *connection.DBManager
+	settingService ISettingService
 }
 
-func NewProgressService(dbManager *connection.DBManager) *ProgressService {
+func NewProgressService(dbManager *connection.DBManager, settingService ISettingService) *ProgressService {
 	return &ProgressService{
-		ctx:       context.Background(),
-		dbManager: dbManager,
+		ctx:            context.Background(),
+		dbManager:      dbManager,
+		settingService: settingService,
 	}
 }
 
@@ -44,22 +58,22 @@ func (p *ProgressService) GetStats() (GetStatsResult, error) {
 
 	readQueries := p.dbManager.Queries(p.ctx)
 
-	weekMins, err := readQueries.AggregateWeekHours(p.ctx, int64(1))
+	weekSecs, err := readQueries.AggregateWeekHours(p.ctx, int64(1))
 	if err != nil {
 		return result, err
 	}
 
-	prevWeekMins, err := readQueries.AggregatePreviousWeekHours(p.ctx, int64(1))
+	prevWeekSecs, err := readQueries.AggregatePreviousWeekHours(p.ctx, int64(1))
 	if err != nil {
 		return result, err
 	}
 
-	monthMins, err := readQueries.AggregateMonthHours(p.ctx, int64(1))
+	monthSecs, err := readQueries.AggregateMonthHours(p.ctx, int64(1))
 	if err != nil {
 		return result, err
 	}
 
-	prevMonthMins, err := readQueries.AggregatePreviousMonthHours(p.ctx, int64(1))
+	prevMonthSecs, err := readQueries.AggregatePreviousMonthHours(p.ctx, int64(1))
 	if err != nil {
 		return result, err
 	}
@@ -84,11 +98,11 @@ func (p *ProgressService) GetStats() (GetStatsResult, error) {
 		return result, err
 	}
 
-	// Convert to hours from mins
-	weekHours := math.Ceil(weekMins / 60.0)
-	monthHours := math.Ceil(monthMins / 60.0)
-	prevWeekHours := math.Ceil(prevWeekMins / 60.0)
-	prevMonthHours := math.Ceil(prevMonthMins / 60.0)
+	// Convert to hours from seconds
+	weekHours := math.Ceil(weekSecs / 3600.0)
+	monthHours := math.Ceil(monthSecs / 3600.0)
+	prevWeekHours := math.Ceil(prevWeekSecs / 3600.0)
+	prevMonthHours := math.Ceil(prevMonthSecs / 3600.0)
 
 	result = GetStatsResult{
 		WeekHrs:       StatCardData{Value: int(weekHours), PrevValue: int(prevWeekHours)},
@@ -116,3 +130,28 @@ func (p *ProgressService) GetTotalExpForUser(userID int64) (float64, error) {
 	}
 	return totalExp, nil
 }
+
+// GetLevelInfo returns the user's level using the currently configured curve, so curve
+// changes are reflected immediately.
+func (p *ProgressService) GetLevelInfo(userID int64) (LevelInfo, error) {
+	readQueries := p.dbManager.Queries(p.ctx)
+	progression, err := readQueries.GetUserProgression(p.ctx, userID)
+	if err != nil {
+		return LevelInfo{}, err
+	}
+
+	level, expIntoLevel, expToNextLevel := loadLevelCurve(p.settingService).LevelForExp(progression.ArcherExperience)
+	return LevelInfo{
+		Level:             level,
+		TotalExp:          progression.ArcherExperience,
+		ExpIntoLevel:      expIntoLevel,
+		ExpToNextLevel:    expToNextLevel,
+		ProgressToNext:    float64(expIntoLevel) / float64(expToNextLevel),
+		ProgressionPoints: progression.ProgressionPoints,
+	}, nil
+}
+
+func (p *ProgressService) GetLevelHistory(userID int64) ([]database.LevelHistory, error) {
+	readQueries := p.dbManager.Queries(p.ctx)
+	return readQueries.ListLevelHistory(p.ctx, userID)
+}
@@ -2,19 +2,35 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/sriram15/progressor-todo-app/internal/connection"
 	"github.com/sriram15/progressor-todo-app/internal/database"
 )
 
+var (
+	ErrProjectNameRequired    = errors.New("project name is required")
+	ErrDefaultProjectReadOnly = errors.New("the default project cannot be archived or deleted")
+	ErrInvalidReassignProject = errors.New("cards must be reassigned to a different, existing project")
+)
+
 type IProjectService interface {
 	IsValidProject(projectId uint) (bool, error)
 	AddProjectSkill(ctx context.Context, projectID, skillID int64) error
 	RemoveProjectSkill(ctx context.Context, projectID, skillID int64) error
 	GetSkillsForProject(ctx context.Context, projectID int64) ([]database.UserSkill, error)
 	GetProjects() ([]database.Project, error)
+	GetArchivedProjects(ctx context.Context) ([]database.Project, error)
+	CreateProject(ctx context.Context, name string) (*database.Project, error)
+	RenameProject(ctx context.Context, projectID int64, name string) error
+	ArchiveProject(ctx context.Context, projectID int64) error
+	UnarchiveProject(ctx context.Context, projectID int64) error
+	DeleteProject(ctx context.Context, projectID int64, reassignToProjectID int64) error
 }
 
 type ProjectService struct {
@@ -27,14 +43,19 @@ func NewProjectService(dbManager *connection.DBManager) *ProjectService {
 	}
 }
 
+// IsValidProject reports whether a project with the given ID exists. Archived projects
+// are still valid so that their cards remain readable.
 func (p *ProjectService) IsValidProject(projectId uint) (bool, error) {
-
-	// TODO: Access the DB adn validate the projectId
-	// Returning based ont he default project for now.
-	if projectId == 1 {
-		return true, nil
+	ctx := context.Background()
+	queries := p.dbManager.Queries(ctx)
+	if _, err := queries.GetProject(ctx, int64(projectId)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, ErrInvalidProject
+		}
+		log.Printf("Error validating project %d: %v", projectId, err)
+		return false, fmt.Errorf("failed to validate project: %w", err)
 	}
-	return false, ErrInvalidProject
+	return true, nil
 }
 
 func (p *ProjectService) AddProjectSkill(ctx context.Context, projectID, skillID int64) error {
@@ -75,8 +96,167 @@ func (p *ProjectService) GetSkillsForProject(ctx context.Context, projectID int6
 	return skills, nil
 }
 
+// GetProjects returns all projects that have not been archived.
 func (p *ProjectService) GetProjects() ([]database.Project, error) {
-	return []database.Project{
-		{ID: 1, Name: "Inbox"},
-	}, nil
+	ctx := context.Background()
+	queries := p.dbManager.Queries(ctx)
+	projects, err := queries.ListProjects(ctx)
+	if err != nil {
+		log.Printf("Error listing projects: %v", err)
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	return projects, nil
+}
+
+// GetArchivedProjects returns archived projects, most recently archived first.
+func (p *ProjectService) GetArchivedProjects(ctx context.Context) ([]database.Project, error) {
+	queries := p.dbManager.Queries(ctx)
+	projects, err := queries.ListArchivedProjects(ctx)
+	if err != nil {
+		log.Printf("Error listing archived projects: %v", err)
+		return nil, fmt.Errorf("failed to list archived projects: %w", err)
+	}
+	return projects, nil
+}
+
+func (p *ProjectService) CreateProject(ctx context.Context, name string) (*database.Project, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrProjectNameRequired
+	}
+
+	var project database.Project
+	err := p.dbManager.Execute(ctx, func(q *database.Queries) error {
+		var err error
+		project, err = createProject(ctx, q, name)
+		return err
+	})
+	if err != nil {
+		log.Printf("Error creating project: %v", err)
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+	return &project, nil
+}
+
+func (p *ProjectService) RenameProject(ctx context.Context, projectID int64, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrProjectNameRequired
+	}
+
+	return p.dbManager.Execute(ctx, func(q *database.Queries) error {
+		if _, err := getProjectForUpdate(ctx, q, projectID); err != nil {
+			return err
+		}
+		if err := q.RenameProject(ctx, database.RenameProjectParams{ID: projectID, Name: name}); err != nil {
+			log.Printf("Error renaming project: %v", err)
+			return fmt.Errorf("failed to rename project: %w", err)
+		}
+		return nil
+	})
+}
+
+// ArchiveProject hides a project from GetProjects without touching its cards.
+func (p *ProjectService) ArchiveProject(ctx context.Context, projectID int64) error {
+	return p.setArchivedAt(ctx, projectID, sql.NullTime{Valid: true, Time: time.Now().UTC()})
+}
+
+func (p *ProjectService) UnarchiveProject(ctx context.Context, projectID int64) error {
+	return p.setArchivedAt(ctx, projectID, sql.NullTime{})
+}
+
+func (p *ProjectService) setArchivedAt(ctx context.Context, projectID int64, archivedAt sql.NullTime) error {
+	if projectID == DefaultProjectID {
+		return ErrDefaultProjectReadOnly
+	}
+
+	return p.dbManager.Execute(ctx, func(q *database.Queries) error {
+		if _, err := getProjectForUpdate(ctx, q, projectID); err != nil {
+			return err
+		}
+		if err := q.SetProjectArchivedAt(ctx, database.SetProjectArchivedAtParams{ID: projectID, ArchivedAt: archivedAt}); err != nil {
+			log.Printf("Error updating project archive state: %v", err)
+			return fmt.Errorf("failed to update project archive state: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteProject removes a project after moving all of its cards, including their time
+// entries and completions, to reassignToProjectID. Moved cards land in the first status of
+// the new project's workflow that matches whether they are done. Skill associations and the
+// workflow of the deleted project are dropped.
+func (p *ProjectService) DeleteProject(ctx context.Context, projectID int64, reassignToProjectID int64) error {
+	if projectID == DefaultProjectID {
+		return ErrDefaultProjectReadOnly
+	}
+	if projectID == reassignToProjectID {
+		return ErrInvalidReassignProject
+	}
+
+	return p.dbManager.Execute(ctx, func(q *database.Queries) error {
+		if _, err := getProjectForUpdate(ctx, q, projectID); err != nil {
+			return err
+		}
+		if _, err := getProjectForUpdate(ctx, q, reassignToProjectID); err != nil {
+			if errors.Is(err, ErrInvalidProject) {
+				return ErrInvalidReassignProject
+			}
+			return err
+		}
+
+		err := q.ReassignProjectCards(ctx, database.ReassignProjectCardsParams{
+			TargetProjectID: reassignToProjectID,
+			SourceProjectID: projectID,
+		})
+		if err != nil {
+			log.Printf("Error reassigning cards of project %d: %v", projectID, err)
+			return fmt.Errorf("failed to reassign project cards: %w", err)
+		}
+
+		if err := q.RemoveAllProjectSkills(ctx, projectID); err != nil {
+			log.Printf("Error removing skills of project %d: %v", projectID, err)
+			return fmt.Errorf("failed to remove project skills: %w", err)
+		}
+
+		if err := q.DeleteWorkflowTransitionsForProject(ctx, projectID); err != nil {
+			log.Printf("Error removing workflow of project %d: %v", projectID, err)
+			return fmt.Errorf("failed to remove project workflow: %w", err)
+		}
+		if err := q.DeleteWorkflowStatusesForProject(ctx, projectID); err != nil {
+			log.Printf("Error removing workflow of project %d: %v", projectID, err)
+			return fmt.Errorf("failed to remove project workflow: %w", err)
+		}
+
+		if err := q.DeleteProject(ctx, projectID); err != nil {
+			log.Printf("Error deleting project %d: %v", projectID, err)
+			return fmt.Errorf("failed to delete project: %w", err)
+		}
+		return nil
+	})
+}
+
+// createProject adds a project with the default workflow inside a transaction. Every project is
+// created through it.
+func createProject(ctx context.Context, q *database.Queries, name string) (database.Project, error) {
+	project, err := q.CreateProject(ctx, name)
+	if err != nil {
+		return database.Project{}, err
+	}
+	if err := createDefaultWorkflow(ctx, q, project.ID); err != nil {
+		return database.Project{}, err
+	}
+	return project, nil
+}
+
+// getProjectForUpdate loads a project inside a transaction, mapping a missing row to ErrInvalidProject.
+func getProjectForUpdate(ctx context.Context, q *database.Queries, projectID int64) (database.Project, error) {
+	project, err := q.GetProject(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return database.Project{}, ErrInvalidProject
+		}
+		return database.Project{}, fmt.Errorf("failed to get project: %w", err)
+	}
+	return project, nil
 }
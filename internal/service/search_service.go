@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"unicode"
+
+	"github.com/sriram15/progressor-todo-app/internal/connection"
+	"github.com/sriram15/progressor-todo-app/internal/database"
+)
+
+const (
+	defaultSearchLimit = 50
+	maxSearchLimit     = 200
+)
+
+// Markers the SearchCards query puts around matched words in a snippet, replaced by <mark> tags
+// once the snippet is escaped.
+const (
+	snippetMatchStart = "\x02"
+	snippetMatchEnd   = "\x03"
+)
+
+// SearchOptions narrows SearchCards. The zero value searches all cards of every project.
+type SearchOptions struct {
+	// ProjectID limits the search to one project when it is not 0.
+	ProjectID uint `json:"projectId"`
+	// Status limits the search to cards with this status when it is set.
+	Status *CardStatus `json:"status"`
+	// Limit caps the number of results, defaulting to defaultSearchLimit.
+	Limit int `json:"limit"`
+}
+
+type ISearchService interface {
+	SearchCards(query string, options SearchOptions) ([]database.SearchCardsRow, error)
+}
+
+type SearchService struct {
+	ctx            context.Context
+	projectService IProjectService
+	dbManager      *connection.DBManager
+}
+
+func NewSearchService(projectService IProjectService, dbManager *connection.DBManager) *SearchService {
+	return &SearchService{
+		ctx:            context.Background(),
+		projectService: projectService,
+		dbManager:      dbManager,
+	}
+}
+
+// SearchCards finds the cards whose title, description or checklist contain every word of
+// query, matching words by prefix. The best matches come first, weighing the title highest.
+// Snippets are HTML with the matched words wrapped in <mark> tags.
+func (s *SearchService) SearchCards(query string, options SearchOptions) ([]database.SearchCardsRow, error) {
+	match := searchMatchExpression(query)
+	if match == "" {
+		return []database.SearchCardsRow{}, nil
+	}
+
+	if options.ProjectID != 0 {
+		if _, err := s.projectService.IsValidProject(options.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+
+	params := database.SearchCardsParams{
+		Query:       match,
+		AllProjects: options.ProjectID == 0,
+		ProjectID:   int64(options.ProjectID),
+		AllStatuses: options.Status == nil,
+		MaxResults:  defaultSearchLimit,
+	}
+	if options.Status != nil {
+		params.Status = int64(*options.Status)
+	}
+	if options.Limit > 0 {
+		params.MaxResults = int64(min(options.Limit, maxSearchLimit))
+	}
+
+	queries := s.dbManager.Queries(s.ctx)
+	results, err := queries.SearchCards(s.ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search cards: %w", err)
+	}
+	for i := range results {
+		results[i].Snippet = highlightSnippet(results[i].Snippet)
+	}
+	return results, nil
+}
+
+// searchMatchExpression turns free text into an FTS5 query requiring every word as a prefix.
+// Only letters and digits are kept, so FTS5 syntax in the text is not interpreted.
+func searchMatchExpression(query string) string {
+	words := strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = `"` + word + `"*`
+	}
+	return strings.Join(terms, " ")
+}
+
+func highlightSnippet(snippet string) string {
+	snippet = html.EscapeString(snippet)
+	snippet = strings.ReplaceAll(snippet, snippetMatchStart, "<mark>")
+	return strings.ReplaceAll(snippet, snippetMatchEnd, "</mark>")
+}
@@ -1,54 +1,332 @@
 package service
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/sriram15/progressor-todo-app/internal/connection"
+	"github.com/sriram15/progressor-todo-app/internal/database"
+	"github.com/sriram15/progressor-todo-app/internal/events"
 )
 
+var (
+	ErrUnknownSetting      = errors.New("unknown setting")
+	ErrReadOnlySetting     = errors.New("setting is read-only")
+	ErrInvalidSettingValue = errors.New("invalid setting value")
+)
+
+// SettingType describes how a setting's value is parsed and validated.
+type SettingType string
+
+const (
+	SettingTypeString   SettingType = "string"
+	SettingTypeInt      SettingType = "int"
+	SettingTypeBool     SettingType = "bool"
+	SettingTypeDuration SettingType = "duration"
+	SettingTypeEnum     SettingType = "enum"
+)
+
+// SettingDefinition is the schema of a single setting.
+type SettingDefinition struct {
+	Key     string
+	Display string
+	Type    SettingType
+	// Default is in the canonical form returned by normalizeSettingValue.
+	Default string
+	// Min and Max bound int settings, and duration settings in seconds. Both zero means unbounded.
+	Min      int64
+	Max      int64
+	Options  []string
+	ReadOnly bool
+}
+
+// settingDefinitions lists every setting the app understands, in display order.
+var settingDefinitions = []SettingDefinition{
+	{Key: "dbType", Display: "Database Type", Type: SettingTypeString, ReadOnly: true},
+	{Key: "dbPath", Display: "Database Path", Type: SettingTypeString, ReadOnly: true},
+	{Key: "shortcut_open", Display: "Shortcut - Open App", Type: SettingTypeString, Default: "Ctrl + Shift + P", ReadOnly: true},
+	{Key: "active_card_timeout", Display: "Active Card Timeout (minutes)", Type: SettingTypeInt, Default: "1", Min: 1, Max: 480},
+	{Key: "streak_grace_days", Display: "Streak Grace Days", Type: SettingTypeInt, Default: "1", Min: 0, Max: 7},
+	{Key: "level_curve", Display: "Level Curve", Type: SettingTypeEnum, Default: LevelCurveLinear,
+		Options: []string{LevelCurveLinear, LevelCurveQuadratic, LevelCurveExponential}},
+	{Key: "level_curve_base", Display: "Level Curve Base EXP", Type: SettingTypeInt, Default: "100", Min: 1, Max: 100000},
+	{Key: "stale_entry_policy", Display: "Time Left Running After A Crash", Type: SettingTypeEnum, Default: StaleEntryPolicyCloseAtLastSeen,
+		Options: []string{StaleEntryPolicyCloseAtLastSeen, StaleEntryPolicyAsk}},
+	{Key: "idle_gap_threshold", Display: "Idle Gap Threshold", Type: SettingTypeDuration, Default: "5m0s", Min: 60, Max: 86400},
+	{Key: "due_reminder_lead", Display: "Due Date Reminder Lead Time", Type: SettingTypeDuration, Default: "30m0s", Min: 60, Max: 604800},
+	{Key: "trash_retention_days", Display: "Days To Keep Deleted Cards", Type: SettingTypeInt, Default: "30", Min: 1, Max: 3650},
+	{Key: "focus_extend_duration", Display: "Focus Extension Length", Type: SettingTypeDuration, Default: "5m0s", Min: 60, Max: 7200},
+	{Key: "pomodoro_enabled", Display: "Pomodoro Mode", Type: SettingTypeBool, Default: "false"},
+	{Key: "pomodoro_work_duration", Display: "Pomodoro Work Length", Type: SettingTypeDuration, Default: "25m0s", Min: 60, Max: 14400},
+	{Key: "pomodoro_short_break_duration", Display: "Pomodoro Short Break Length", Type: SettingTypeDuration, Default: "5m0s", Min: 60, Max: 3600},
+	{Key: "pomodoro_long_break_duration", Display: "Pomodoro Long Break Length", Type: SettingTypeDuration, Default: "15m0s", Min: 60, Max: 7200},
+	{Key: "pomodoro_cycles_before_long_break", Display: "Pomodoro Sessions Before Long Break", Type: SettingTypeInt, Default: "4", Min: 1, Max: 12},
+}
+
 type ISettingService interface {
 	GetAllSettings() ([]SettingsItem, error)
 	GetSetting(key string) (string, error)
 	SetSetting(key, value string) error
+	ResetSetting(key string) error
+	GetIntSetting(key string) (int64, error)
+	GetBoolSetting(key string) (bool, error)
+	GetDurationSetting(key string) (time.Duration, error)
 }
 
 type SettingService struct {
+	ctx       context.Context
 	dbManager *connection.DBManager
-	settings  []SettingsItem
+	eventBus  *events.EventBus
+	// runtimeValues holds read-only values that describe the running session rather than user choices.
+	runtimeValues map[string]string
 }
 
 type SettingsItem struct {
-	Key     string `json:"key"`
-	Value   string `json:"value"`
-	Display string `json:"display"`
+	Key      string      `json:"key"`
+	Value    string      `json:"value"`
+	Display  string      `json:"display"`
+	Type     SettingType `json:"type"`
+	Default  string      `json:"default"`
+	Options  []string    `json:"options,omitempty"`
+	ReadOnly bool        `json:"readOnly"`
 }
 
-func NewSettingService(dbManager *connection.DBManager) *SettingService {
+func NewSettingService(dbManager *connection.DBManager, eventBus *events.EventBus) *SettingService {
 	dbType, dbPath := connection.GetDBInfo()
-	settings := []SettingsItem{
-		{Key: "dbType", Value: dbType, Display: "Database Type" },
-		{Key: "dbPath", Value: dbPath, Display: "Database Path"},
-		{Key: "shortcut_open", Value: "Ctrl + Shift + P", Display: "Shortcut - Open App"},
-		{Key: "active_card_timeout", Value: "1", Display: "Active Card Timeout (minutes)"},
+	return &SettingService{
+		ctx:       context.Background(),
+		dbManager: dbManager,
+		eventBus:  eventBus,
+		runtimeValues: map[string]string{
+			"dbType": dbType,
+			"dbPath": dbPath,
+		},
 	}
-
-	return &SettingService{dbManager: dbManager, settings: settings}
 }
 
+// GetAllSettings returns every known setting with its effective value.
 func (s *SettingService) GetAllSettings() ([]SettingsItem, error) {
-	return s.settings, nil
+	stored, err := s.dbManager.Queries(s.ctx).ListSettings(s.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list settings: %w", err)
+	}
+
+	storedValues := make(map[string]string, len(stored))
+	for _, setting := range stored {
+		storedValues[setting.Key] = setting.Value
+	}
+
+	items := make([]SettingsItem, 0, len(settingDefinitions))
+	for _, def := range settingDefinitions {
+		value, ok := storedValues[def.Key]
+		items = append(items, SettingsItem{
+			Key:      def.Key,
+			Value:    s.effectiveValue(def, value, ok),
+			Display:  def.Display,
+			Type:     def.Type,
+			Default:  def.Default,
+			Options:  def.Options,
+			ReadOnly: def.ReadOnly,
+		})
+	}
+	return items, nil
 }
 
+// GetSetting returns the stored value of a setting, or its default when it was never set.
 func (s *SettingService) GetSetting(key string) (string, error) {
-	for _, setting_i := range s.settings {
-		if setting_i.Key == key {
-			return setting_i.Value, nil
-		}
+	def, err := findSettingDefinition(key)
+	if err != nil {
+		return "", err
+	}
+
+	value, err := s.dbManager.Queries(s.ctx).GetSettingValue(s.ctx, key)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("failed to get setting '%s': %w", key, err)
 	}
-	return "", fmt.Errorf("setting with key '%s' not found", key)
+	return s.effectiveValue(def, value, err == nil), nil
 }
 
+// SetSetting validates value against the setting's schema and persists it in its canonical form.
 func (s *SettingService) SetSetting(key, value string) error {
-	// TODO: Implement saving to db
+	def, err := findSettingDefinition(key)
+	if err != nil {
+		return err
+	}
+	if def.ReadOnly {
+		return fmt.Errorf("%w: %s", ErrReadOnlySetting, key)
+	}
+
+	normalized, err := normalizeSettingValue(def, value)
+	if err != nil {
+		return err
+	}
+
+	oldValue, err := s.GetSetting(key)
+	if err != nil {
+		return err
+	}
+
+	err = s.dbManager.Execute(s.ctx, func(q *database.Queries) error {
+		return q.UpsertSetting(s.ctx, database.UpsertSettingParams{Key: key, Value: normalized})
+	})
+	if err != nil {
+		return err
+	}
+
+	s.publishChange(key, oldValue, normalized)
 	return nil
 }
+
+// ResetSetting removes a stored value so the setting falls back to its default.
+func (s *SettingService) ResetSetting(key string) error {
+	def, err := findSettingDefinition(key)
+	if err != nil {
+		return err
+	}
+	if def.ReadOnly {
+		return fmt.Errorf("%w: %s", ErrReadOnlySetting, key)
+	}
+
+	oldValue, err := s.GetSetting(key)
+	if err != nil {
+		return err
+	}
+
+	err = s.dbManager.Execute(s.ctx, func(q *database.Queries) error {
+		return q.DeleteSetting(s.ctx, key)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.publishChange(key, oldValue, def.Default)
+	return nil
+}
+
+func (s *SettingService) GetIntSetting(key string) (int64, error) {
+	value, err := s.getTypedSetting(key, SettingTypeInt)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
+
+func (s *SettingService) GetBoolSetting(key string) (bool, error) {
+	value, err := s.getTypedSetting(key, SettingTypeBool)
+	if err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(value)
+}
+
+func (s *SettingService) GetDurationSetting(key string) (time.Duration, error) {
+	value, err := s.getTypedSetting(key, SettingTypeDuration)
+	if err != nil {
+		return 0, err
+	}
+	return time.ParseDuration(value)
+}
+
+func (s *SettingService) getTypedSetting(key string, settingType SettingType) (string, error) {
+	def, err := findSettingDefinition(key)
+	if err != nil {
+		return "", err
+	}
+	if def.Type != settingType {
+		return "", fmt.Errorf("setting '%s' is of type %s, not %s", key, def.Type, settingType)
+	}
+	return s.GetSetting(key)
+}
+
+// publishChange notifies subscribers that a setting's effective value changed, so they can
+// reconfigure without a restart. Writes that keep the same value are not published.
+func (s *SettingService) publishChange(key, oldValue, newValue string) {
+	if oldValue == newValue {
+		return
+	}
+	s.eventBus.Publish(events.SettingChangedTopic, events.SettingChangedEvent{
+		Key:       key,
+		OldValue:  oldValue,
+		NewValue:  newValue,
+		ChangedAt: time.Now().UTC(),
+	})
+}
+
+// effectiveValue resolves what a setting currently is. Stored values that no longer pass
+// validation, e.g. after a schema change, fall back to the default.
+func (s *SettingService) effectiveValue(def SettingDefinition, stored string, hasStored bool) string {
+	if value, ok := s.runtimeValues[def.Key]; ok {
+		return value
+	}
+	if !hasStored {
+		return def.Default
+	}
+
+	normalized, err := normalizeSettingValue(def, stored)
+	if err != nil {
+		log.Printf("Ignoring stored value for setting '%s': %v", def.Key, err)
+		return def.Default
+	}
+	return normalized
+}
+
+func findSettingDefinition(key string) (SettingDefinition, error) {
+	for _, def := range settingDefinitions {
+		if def.Key == key {
+			return def, nil
+		}
+	}
+	return SettingDefinition{}, fmt.Errorf("%w: '%s'", ErrUnknownSetting, key)
+}
+
+// normalizeSettingValue validates value against def and returns its canonical string form.
+func normalizeSettingValue(def SettingDefinition, value string) (string, error) {
+	value = strings.TrimSpace(value)
+
+	switch def.Type {
+	case SettingTypeInt:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s must be a whole number", ErrInvalidSettingValue, def.Key)
+		}
+		if (def.Min != 0 || def.Max != 0) && (n < def.Min || n > def.Max) {
+			return "", fmt.Errorf("%w: %s must be between %d and %d", ErrInvalidSettingValue, def.Key, def.Min, def.Max)
+		}
+		return strconv.FormatInt(n, 10), nil
+
+	case SettingTypeBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s must be true or false", ErrInvalidSettingValue, def.Key)
+		}
+		return strconv.FormatBool(b), nil
+
+	case SettingTypeDuration:
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return "", fmt.Errorf("%w: %s must be a positive duration such as 25m", ErrInvalidSettingValue, def.Key)
+		}
+		minimum, maximum := time.Duration(def.Min)*time.Second, time.Duration(def.Max)*time.Second
+		if (def.Min != 0 || def.Max != 0) && (d < minimum || d > maximum) {
+			return "", fmt.Errorf("%w: %s must be between %s and %s", ErrInvalidSettingValue, def.Key, minimum, maximum)
+		}
+		return d.String(), nil
+
+	case SettingTypeEnum:
+		value = strings.ToLower(value)
+		if !slices.Contains(def.Options, value) {
+			return "", fmt.Errorf("%w: %s must be one of %s", ErrInvalidSettingValue, def.Key, strings.Join(def.Options, ", "))
+		}
+		return value, nil
+
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeSettingValue(t *testing.T) {
+	intDef := SettingDefinition{Key: "int", Type: SettingTypeInt, Min: 1, Max: 10}
+	unboundedDef := SettingDefinition{Key: "unbounded", Type: SettingTypeInt}
+	boolDef := SettingDefinition{Key: "bool", Type: SettingTypeBool}
+	durationDef := SettingDefinition{Key: "duration", Type: SettingTypeDuration, Min: 60, Max: 3600}
+	enumDef := SettingDefinition{Key: "enum", Type: SettingTypeEnum, Options: []string{"linear", "quadratic"}}
+
+	tests := []struct {
+		name    string
+		def     SettingDefinition
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "int within bounds", def: intDef, value: " 05 ", want: "5"},
+		{name: "int at lower bound", def: intDef, value: "1", want: "1"},
+		{name: "int at upper bound", def: intDef, value: "10", want: "10"},
+		{name: "int below bounds", def: intDef, value: "0", wantErr: true},
+		{name: "int above bounds", def: intDef, value: "11", wantErr: true},
+		{name: "int not a number", def: intDef, value: "five", wantErr: true},
+		{name: "unbounded int", def: unboundedDef, value: "-20", want: "-20"},
+		{name: "bool", def: boolDef, value: "TRUE", want: "true"},
+		{name: "bool shorthand", def: boolDef, value: "0", want: "false"},
+		{name: "bool invalid", def: boolDef, value: "yes", wantErr: true},
+		{name: "duration", def: durationDef, value: "25m", want: "25m0s"},
+		{name: "duration equivalent spelling", def: durationDef, value: "1500s", want: "25m0s"},
+		{name: "duration canonical", def: durationDef, value: "25m0s", want: "25m0s"},
+		{name: "duration below bounds", def: durationDef, value: "30s", wantErr: true},
+		{name: "duration above bounds", def: durationDef, value: "2h", wantErr: true},
+		{name: "duration not positive", def: durationDef, value: "-5m", wantErr: true},
+		{name: "duration without unit", def: durationDef, value: "25", wantErr: true},
+		{name: "enum", def: enumDef, value: " Quadratic ", want: "quadratic"},
+		{name: "enum unknown option", def: enumDef, value: "cubic", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeSettingValue(tt.def, tt.value)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSettingValue) {
+					t.Errorf("normalizeSettingValue(%q) error = %v, want ErrInvalidSettingValue", tt.value, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("normalizeSettingValue(%q) unexpected error: %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeSettingValue(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSettingDefaultsAreCanonical(t *testing.T) {
+	for _, def := range settingDefinitions {
+		if def.ReadOnly {
+			continue
+		}
+		got, err := normalizeSettingValue(def, def.Default)
+		if err != nil {
+			t.Errorf("default of %s is invalid: %v", def.Key, err)
+			continue
+		}
+		if got != def.Default {
+			t.Errorf("default of %s = %q, canonical form is %q", def.Key, def.Default, got)
+		}
+	}
+}
@@ -3,8 +3,10 @@ package service
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/sriram15/progressor-todo-app/internal/connection"
 	"github.com/sriram15/progressor-todo-app/internal/database"
@@ -124,6 +126,8 @@ func (s *SkillService) GetUserSkillProgress(ctx context.Context, userID, skillID
 
 func (s *SkillService) RegisterEventHandlers() {
 	s.eventBus.Subscribe(events.CardStoppedTopic, s.handleCardStopped)
+	s.eventBus.Subscribe(events.TimeEntryChangedTopic, s.handleTimeEntryChanged)
+	s.eventBus.Subscribe(events.CardsBulkChangedTopic, s.handleCardsBulkChanged)
 }
 
 func (s *SkillService) handleCardStopped(eventData interface{}) {
@@ -134,23 +138,81 @@ func (s *SkillService) handleCardStopped(eventData interface{}) {
 	}
 	log.Printf("Received CardStoppedEvent: %+v", event)
 
-	ctx := context.Background()
+	s.applySkillSeconds(context.Background(), event.UserID, event.ProjectID, int64(event.TimeSpent/time.Second))
+}
 
-	projectSkills, err := s.projectService.GetSkillsForProject(ctx, event.ProjectID)
-	if err != nil {
-		log.Printf("Error getting skills for project %d: %v", event.ProjectID, err)
+func (s *SkillService) handleTimeEntryChanged(eventData interface{}) {
+	event, ok := eventData.(events.TimeEntryChangedEvent)
+	if !ok {
+		log.Printf("Error: received non-TimeEntryChangedEvent for topic %s", events.TimeEntryChangedTopic)
+		return
+	}
+	log.Printf("Received TimeEntryChangedEvent: %+v", event)
+
+	s.applySkillSeconds(context.Background(), event.UserID, event.ProjectID, int64(event.Delta/time.Second))
+}
+
+func (s *SkillService) handleCardsBulkChanged(eventData interface{}) {
+	event, ok := eventData.(events.CardsBulkChangedEvent)
+	if !ok {
+		log.Printf("Error: received non-CardsBulkChangedEvent for topic %s", events.CardsBulkChangedTopic)
+		return
+	}
+	log.Printf("Received CardsBulkChangedEvent: %+v", event)
+
+	for projectID, delta := range event.TimeDeltas {
+		s.applySkillSeconds(context.Background(), event.UserID, projectID, int64(delta/time.Second))
+	}
+}
+
+// applySkillSeconds adds durationSecs to the user's progress for every skill associated with
+// the project. Negative values remove time, never taking a skill below zero.
+func (s *SkillService) applySkillSeconds(ctx context.Context, userID int64, projectID int64, durationSecs int64) {
+	if durationSecs == 0 {
 		return
 	}
 
-	durationMins := int64(event.TimeSpent.Minutes())
+	projectSkills, err := s.projectService.GetSkillsForProject(ctx, projectID)
+	if err != nil {
+		log.Printf("Error getting skills for project %d: %v", projectID, err)
+		return
+	}
 
 	// Upsert the user's skill progress for each skill associated with the project.
 	err = s.dbManager.Execute(ctx, func(q *database.Queries) error {
 		for _, skill := range projectSkills {
+			if durationSecs < 0 {
+				progress, err := q.GetUserSkillProgress(ctx, database.GetUserSkillProgressParams{
+					UserID:  userID,
+					SkillID: skill.ID,
+				})
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
+				if err != nil {
+					log.Printf("Error getting skill progress for skill %d: %v", skill.ID, err)
+					return err
+				}
+
+				totalSecs := max(progress.TotalSecondsTracked+durationSecs, 0)
+				err = q.SetUserSkillProgressTime(ctx, database.SetUserSkillProgressTimeParams{
+					TotalSecondsTracked: totalSecs,
+					TotalMinutesTracked: sql.NullInt64{Int64: totalSecs / 60, Valid: true},
+					ID:                  progress.ID,
+				})
+				if err != nil {
+					log.Printf("Error adjusting skill progress for skill %d: %v", skill.ID, err)
+					return err
+				}
+				log.Printf("Successfully reduced skill progress for skill %d by %d seconds.", skill.ID, -durationSecs)
+				continue
+			}
+
 			_, err := q.UpsertUserSkillProgress(ctx, database.UpsertUserSkillProgressParams{
-				UserID:              event.UserID,
+				UserID:              userID,
 				SkillID:             skill.ID,
-				TotalMinutesTracked: sql.NullInt64{Int64: durationMins, Valid: true},
+				TotalSecondsTracked: durationSecs,
+				TotalMinutesTracked: sql.NullInt64{Int64: durationSecs / 60, Valid: true},
 			})
 			if err != nil {
 				log.Printf("Error upserting skill progress for skill %d: %v", skill.ID, err)
@@ -158,7 +220,7 @@ func (s *SkillService) handleCardStopped(eventData interface{}) {
 				// For now, we log and continue, but you might want to return the error.
 				return err
 			}
-			log.Printf("Successfully updated skill progress for skill %d by %d minutes.", skill.ID, durationMins)
+			log.Printf("Successfully updated skill progress for skill %d by %d seconds.", skill.ID, durationSecs)
 		}
 		return nil
 	})
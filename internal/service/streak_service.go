@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/sriram15/progressor-todo-app/internal/connection"
+	"github.com/sriram15/progressor-todo-app/internal/database"
+)
+
+const (
+	// streakDayLayout is the format of the days returned by the activity queries.
+	streakDayLayout = "2006-01-02"
+
+	defaultStreakGraceDays = 1
+
+	// streakBonusExpPerDay is awarded for every day of a streak after the first,
+	// up to maxStreakBonusDays days.
+	streakBonusExpPerDay = 2
+	maxStreakBonusDays   = 10
+)
+
+var ErrInvalidRestDay = errors.New("rest day must be a date in YYYY-MM-DD format")
+
+// StreakInfo describes the user's daily activity streak. A day counts as active when time
+// was tracked or a card was completed on it.
+type StreakInfo struct {
+	CurrentStreak int    `json:"currentStreak"`
+	LongestStreak int    `json:"longestStreak"`
+	ActiveToday   bool   `json:"activeToday"`
+	LastActiveDay string `json:"lastActiveDay"`
+	// GraceDays is the number of consecutive missed days a streak survives.
+	GraceDays int `json:"graceDays"`
+	// MissedDays is the number of grace days the current streak is using right now.
+	MissedDays int `json:"missedDays"`
+}
+
+type IStreakService interface {
+	GetStreak() (StreakInfo, error)
+	StreakBonusForCompletion() (int64, error)
+	GetRestDays() ([]database.StreakRestDay, error)
+	AddRestDay(day string, note string) error
+	RemoveRestDay(day string) error
+}
+
+type StreakService struct {
+	ctx            context.Context
+	dbManager      *connection.DBManager
+	settingService ISettingService
+}
+
+func NewStreakService(dbManager *connection.DBManager, settingService ISettingService) *StreakService {
+	return &StreakService{
+		ctx:            context.Background(),
+		dbManager:      dbManager,
+		settingService: settingService,
+	}
+}
+
+// GetStreak computes the current and longest streaks as of today. Days are local days, like
+// the due date views.
+func (s *StreakService) GetStreak() (StreakInfo, error) {
+	return s.computeStreak(false)
+}
+
+// StreakBonusForCompletion returns the streak bonus EXP for a card completed right now.
+// The completion itself makes today an active day.
+func (s *StreakService) StreakBonusForCompletion() (int64, error) {
+	streak, err := s.computeStreak(true)
+	if err != nil {
+		return 0, err
+	}
+	return streakBonusExp(streak.CurrentStreak), nil
+}
+
+func (s *StreakService) GetRestDays() ([]database.StreakRestDay, error) {
+	queries := s.dbManager.Queries(s.ctx)
+	return queries.ListRestDays(s.ctx)
+}
+
+// AddRestDay plans a rest day. Rest days neither extend nor break a streak.
+func (s *StreakService) AddRestDay(day string, note string) error {
+	day, err := normalizeStreakDay(day)
+	if err != nil {
+		return err
+	}
+
+	return s.dbManager.Execute(s.ctx, func(q *database.Queries) error {
+		return q.UpsertRestDay(s.ctx, database.UpsertRestDayParams{
+			Day:  day,
+			Note: sql.NullString{String: note, Valid: note != ""},
+		})
+	})
+}
+
+func (s *StreakService) RemoveRestDay(day string) error {
+	day, err := normalizeStreakDay(day)
+	if err != nil {
+		return err
+	}
+
+	return s.dbManager.Execute(s.ctx, func(q *database.Queries) error {
+		return q.DeleteRestDay(s.ctx, day)
+	})
+}
+
+func (s *StreakService) computeStreak(assumeActiveToday bool) (StreakInfo, error) {
+	queries := s.dbManager.Queries(s.ctx)
+
+	now := time.Now()
+	activityDays, err := queries.ListActivityDays(s.ctx, localDayOffset(now))
+	if err != nil {
+		return StreakInfo{}, err
+	}
+
+	restDays, err := queries.ListRestDays(s.ctx)
+	if err != nil {
+		return StreakInfo{}, err
+	}
+
+	today := now.Format(streakDayLayout)
+	if assumeActiveToday {
+		activityDays = append(activityDays, today)
+	}
+
+	rest := make([]string, 0, len(restDays))
+	for _, restDay := range restDays {
+		rest = append(rest, restDay.Day)
+	}
+
+	return calculateStreak(activityDays, rest, today, s.graceDays()), nil
+}
+
+// localDayOffset returns the SQLite date modifier that moves a UTC timestamp into the local
+// time zone at t, so that activity is bucketed into the user's days.
+func localDayOffset(t time.Time) string {
+	_, offset := t.Zone()
+	return fmt.Sprintf("%+d minutes", offset/60)
+}
+
+func (s *StreakService) graceDays() int {
+	graceDays, err := s.settingService.GetIntSetting("streak_grace_days")
+	if err != nil {
+		log.Printf("Error getting streak grace days setting: %v", err)
+		return defaultStreakGraceDays
+	}
+	return int(graceDays)
+}
+
+// calculateStreak walks every day from the first active day up to today. Active days extend
+// the running streak, rest days and today (which is not over yet) are neutral, and any other
+// day is a miss. A streak only breaks once more than graceDays consecutive days are missed.
+func calculateStreak(activityDays []string, restDays []string, today string, graceDays int) StreakInfo {
+	info := StreakInfo{GraceDays: graceDays}
+
+	active := make(map[string]bool, len(activityDays))
+	first := ""
+	for _, day := range activityDays {
+		active[day] = true
+		if first == "" || day < first {
+			first = day
+		}
+		if day > info.LastActiveDay {
+			info.LastActiveDay = day
+		}
+	}
+	if first == "" {
+		return info
+	}
+
+	rest := make(map[string]bool, len(restDays))
+	for _, day := range restDays {
+		rest[day] = true
+	}
+
+	start, err := time.Parse(streakDayLayout, first)
+	if err != nil {
+		return info
+	}
+	end, err := time.Parse(streakDayLayout, today)
+	if err != nil {
+		return info
+	}
+
+	run, missed := 0, 0
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		key := day.Format(streakDayLayout)
+		switch {
+		case active[key]:
+			run++
+			missed = 0
+		case rest[key], key == today:
+		default:
+			missed++
+			if missed > graceDays {
+				run, missed = 0, 0
+			}
+		}
+		if run > info.LongestStreak {
+			info.LongestStreak = run
+		}
+	}
+
+	info.CurrentStreak = run
+	info.MissedDays = missed
+	info.ActiveToday = active[today]
+	return info
+}
+
+// streakBonusExp grows with the streak length and is capped so that long streaks stay a
+// reward rather than a pressure.
+func streakBonusExp(streakDays int) int64 {
+	if streakDays < 2 {
+		return 0
+	}
+	days := min(streakDays-1, maxStreakBonusDays)
+	return int64(days * streakBonusExpPerDay)
+}
+
+func normalizeStreakDay(day string) (string, error) {
+	parsed, err := time.Parse(streakDayLayout, strings.TrimSpace(day))
+	if err != nil {
+		return "", ErrInvalidRestDay
+	}
+	return parsed.Format(streakDayLayout), nil
+}
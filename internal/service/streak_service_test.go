@@ -0,0 +1,115 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalculateStreak(t *testing.T) {
+	tests := []struct {
+		name          string
+		activityDays  []string
+		restDays      []string
+		today         string
+		graceDays     int
+		wantCurrent   int
+		wantLongest   int
+		wantMissed    int
+		wantActiveNow bool
+	}{
+		{
+			name:          "consecutive days including today",
+			activityDays:  []string{"2025-01-01", "2025-01-02", "2025-01-03"},
+			today:         "2025-01-03",
+			wantCurrent:   3,
+			wantLongest:   3,
+			wantActiveNow: true,
+		},
+		{
+			name:         "today is not over yet",
+			activityDays: []string{"2025-01-01", "2025-01-02"},
+			today:        "2025-01-03",
+			wantCurrent:  2,
+			wantLongest:  2,
+		},
+		{
+			name:         "missed day breaks streak without grace",
+			activityDays: []string{"2025-01-01", "2025-01-02"},
+			today:        "2025-01-04",
+			wantCurrent:  0,
+			wantLongest:  2,
+		},
+		{
+			name:         "grace day keeps streak alive",
+			activityDays: []string{"2025-01-01", "2025-01-02"},
+			today:        "2025-01-04",
+			graceDays:    1,
+			wantCurrent:  2,
+			wantLongest:  2,
+			wantMissed:   1,
+		},
+		{
+			name:          "rest day is neutral",
+			activityDays:  []string{"2025-01-01", "2025-01-03"},
+			restDays:      []string{"2025-01-02"},
+			today:         "2025-01-03",
+			wantCurrent:   2,
+			wantLongest:   2,
+			wantActiveNow: true,
+		},
+		{
+			name:          "gap longer than grace restarts streak",
+			activityDays:  []string{"2025-01-01", "2025-01-02", "2025-01-05"},
+			today:         "2025-01-05",
+			graceDays:     1,
+			wantCurrent:   1,
+			wantLongest:   2,
+			wantActiveNow: true,
+		},
+		{
+			name:  "no activity",
+			today: "2025-01-05",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calculateStreak(tt.activityDays, tt.restDays, tt.today, tt.graceDays)
+			if got.CurrentStreak != tt.wantCurrent || got.LongestStreak != tt.wantLongest ||
+				got.MissedDays != tt.wantMissed || got.ActiveToday != tt.wantActiveNow {
+				t.Errorf("calculateStreak() = %+v, want current=%d longest=%d missed=%d activeToday=%v",
+					got, tt.wantCurrent, tt.wantLongest, tt.wantMissed, tt.wantActiveNow)
+			}
+		})
+	}
+}
+
+func TestStreakBonusExp(t *testing.T) {
+	tests := map[int]int64{0: 0, 1: 0, 2: 2, 3: 4, maxStreakBonusDays + 1: maxStreakBonusDays * streakBonusExpPerDay, 100: maxStreakBonusDays * streakBonusExpPerDay}
+	for days, want := range tests {
+		if got := streakBonusExp(days); got != want {
+			t.Errorf("streakBonusExp(%d) = %d, want %d", days, got, want)
+		}
+	}
+}
+
+func TestLocalDayOffset(t *testing.T) {
+	tests := []struct {
+		name   string
+		offset int
+		want   string
+	}{
+		{name: "utc", offset: 0, want: "+0 minutes"},
+		{name: "ahead of utc", offset: 5*3600 + 30*60, want: "+330 minutes"},
+		{name: "behind utc", offset: -8 * 3600, want: "-480 minutes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at := time.Date(2025, 1, 1, 21, 0, 0, 0, time.FixedZone(tt.name, tt.offset))
+			if got := localDayOffset(at); got != tt.want {
+				t.Errorf("localDayOffset() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/sriram15/progressor-todo-app/internal/connection"
+	"github.com/sriram15/progressor-todo-app/internal/database"
+)
+
+var (
+	ErrTagNameRequired = errors.New("tag name is required")
+	ErrTagNameTaken    = errors.New("a tag with this name already exists")
+)
+
+// ITagService manages the tags that cards can be labelled with. Tags are shared by all projects.
+type ITagService interface {
+	GetTags() ([]database.Tag, error)
+	CreateTag(name string, color string) (*database.Tag, error)
+	UpdateTag(id int64, name string, color string) error
+	DeleteTag(id int64) error
+	GetTagCounts(projectId uint, status CardStatus) ([]database.CountTagsForProjectRow, error)
+}
+
+type TagService struct {
+	ctx            context.Context
+	projectService IProjectService
+	dbManager      *connection.DBManager
+}
+
+func NewTagService(projectService IProjectService, dbManager *connection.DBManager) *TagService {
+	return &TagService{
+		ctx:            context.Background(),
+		projectService: projectService,
+		dbManager:      dbManager,
+	}
+}
+
+func (t *TagService) GetTags() ([]database.Tag, error) {
+	queries := t.dbManager.Queries(t.ctx)
+	return queries.ListTags(t.ctx)
+}
+
+// CreateTag adds a tag. Names are unique regardless of case; color is optional.
+func (t *TagService) CreateTag(name string, color string) (*database.Tag, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrTagNameRequired
+	}
+
+	var tag database.Tag
+	err := t.dbManager.Execute(t.ctx, func(q *database.Queries) error {
+		if err := checkTagName(t.ctx, q, 0, name); err != nil {
+			return err
+		}
+
+		var err error
+		tag, err = q.CreateTag(t.ctx, database.CreateTagParams{
+			Name:  name,
+			Color: tagColor(color),
+		})
+		return err
+	})
+	if err != nil {
+		log.Printf("Error creating tag: %v", err)
+		return nil, fmt.Errorf("failed to create tag: %w", err)
+	}
+	return &tag, nil
+}
+
+func (t *TagService) UpdateTag(id int64, name string, color string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrTagNameRequired
+	}
+
+	return t.dbManager.Execute(t.ctx, func(q *database.Queries) error {
+		if _, err := getTag(t.ctx, q, id); err != nil {
+			return err
+		}
+		if err := checkTagName(t.ctx, q, id, name); err != nil {
+			return err
+		}
+		return q.UpdateTag(t.ctx, database.UpdateTagParams{
+			ID:    id,
+			Name:  name,
+			Color: tagColor(color),
+		})
+	})
+}
+
+// DeleteTag removes a tag and detaches it from every card.
+func (t *TagService) DeleteTag(id int64) error {
+	return t.dbManager.Execute(t.ctx, func(q *database.Queries) error {
+		if _, err := getTag(t.ctx, q, id); err != nil {
+			return err
+		}
+		if err := q.DeleteCardTagsForTag(t.ctx, id); err != nil {
+			return err
+		}
+		return q.DeleteTag(t.ctx, id)
+	})
+}
+
+// GetTagCounts returns the tags used in a project with the number of cards of the given status
+// that carry them. Tags without such cards are left out.
+func (t *TagService) GetTagCounts(projectId uint, status CardStatus) ([]database.CountTagsForProjectRow, error) {
+	if _, err := t.projectService.IsValidProject(projectId); err != nil {
+		return nil, err
+	}
+
+	queries := t.dbManager.Queries(t.ctx)
+	return queries.CountTagsForProject(t.ctx, database.CountTagsForProjectParams{
+		Projectid: int64(projectId),
+		Status:    int64(status),
+	})
+}
+
+func getTag(ctx context.Context, q *database.Queries, id int64) (database.Tag, error) {
+	tag, err := q.GetTag(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return database.Tag{}, ErrNotFound
+		}
+		return database.Tag{}, err
+	}
+	return tag, nil
+}
+
+// checkTagName rejects a name already used by a tag other than excludeId.
+func checkTagName(ctx context.Context, q *database.Queries, excludeId int64, name string) error {
+	existing, err := q.GetTagByName(ctx, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.ID != excludeId {
+		return ErrTagNameTaken
+	}
+	return nil
+}
+
+func tagColor(color string) sql.NullString {
+	color = strings.TrimSpace(color)
+	return sql.NullString{String: color, Valid: color != ""}
+}
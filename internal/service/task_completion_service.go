@@ -2,45 +2,85 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"log"
 
 	"github.com/sriram15/progressor-todo-app/internal/connection"
 	"github.com/sriram15/progressor-todo-app/internal/database"
+	"github.com/sriram15/progressor-todo-app/internal/events"
 )
 
 type ITaskCompletionService interface {
+	RegisterEventHandlers()
 	CreateTaskCompletion(cardId int64, userId int64, baseExp int64, timeBonusExp int64, streakBonusExp int64) (database.TaskCompletion, error)
+	RecordCompletion(q *database.Queries, cardId int64, userId int64, baseExp int64, timeBonusExp int64, streakBonusExp int64) (database.TaskCompletion, *events.LevelUpEvent, error)
+	RecordChecklistItemCompletion(q *database.Queries, cardId int64, checklistItemId int64, userId int64, exp int64) (database.TaskCompletion, *events.LevelUpEvent, error)
+	PublishLevelUp(event *events.LevelUpEvent)
+	WithdrawCardExperience(q *database.Queries, cardIds []int64, userId int64) (*events.LevelChangedEvent, error)
+	ReturnCardExperience(q *database.Queries, cardId int64, userId int64) (*events.LevelChangedEvent, error)
+	PublishLevelChanged(event *events.LevelChangedEvent)
 	GetTaskCompletion(cardId int64, userId int64) (database.TaskCompletion, error)
 	ListTaskCompletionsByUser(userId int64) ([]database.TaskCompletion, error)
 	TotalUserExp(userId int64) (float64, error)
 }
 
 type TaskCompletionService struct {
-	ctx       context.Context
-	dbManager *connection.DBManager
+	ctx            context.Context
+	dbManager      *connection.DBManager
+	settingService ISettingService
+	eventBus       *events.EventBus
 }
 
-func NewTaskCompletionService(dbManager *connection.DBManager) *TaskCompletionService {
+func NewTaskCompletionService(dbManager *connection.DBManager, settingService ISettingService, eventBus *events.EventBus) *TaskCompletionService {
 	return &TaskCompletionService{
-		ctx:       context.Background(),
-		dbManager: dbManager,
+		ctx:            context.Background(),
+		dbManager:      dbManager,
+		settingService: settingService,
+		eventBus:       eventBus,
+	}
+}
+
+// RegisterEventHandlers subscribes the service to the events it needs to handle.
+func (t *TaskCompletionService) RegisterEventHandlers() {
+	t.eventBus.Subscribe(events.SettingChangedTopic, t.handleSettingChanged)
+}
+
+// handleSettingChanged recomputes the stored level when the level curve changes, so that the
+// level, progression points and level history follow the curve GetLevelInfo uses.
+func (t *TaskCompletionService) handleSettingChanged(eventData interface{}) {
+	event, ok := eventData.(events.SettingChangedEvent)
+	if !ok {
+		log.Printf("Error: received non-SettingChangedEvent for topic %s", events.SettingChangedTopic)
+		return
+	}
+	if event.Key != "level_curve" && event.Key != "level_curve_base" {
+		return
+	}
+
+	var changed events.LevelChangedEvent
+	err := t.dbManager.Execute(t.ctx, func(q *database.Queries) error {
+		var err error
+		changed, err = changeExperience(t.ctx, q, loadLevelCurve(t.settingService), userId, 0)
+		return err
+	})
+	if err != nil {
+		log.Printf("Error recomputing level after %s changed: %v", event.Key, err)
+		return
+	}
+	if changed.NewLevel != changed.PreviousLevel {
+		t.PublishLevelChanged(&changed)
 	}
 }
 
 // CreateTaskCompletion creates a new TaskCompletion record and returns it
 func (t *TaskCompletionService) CreateTaskCompletion(cardId int64, userId int64, baseExp int64, timeBonusExp int64, streakBonusExp int64) (database.TaskCompletion, error) {
-	totalExp := baseExp + timeBonusExp + streakBonusExp
 	var taskValue database.TaskCompletion
+	var levelUp *events.LevelUpEvent
 
 	err := t.dbManager.Execute(t.ctx, func(q *database.Queries) error {
 		var err error
-		taskValue, err = q.CreateTaskCompletion(t.ctx, database.CreateTaskCompletionParams{
-			Cardid:         cardId,
-			Userid:         userId,
-			Baseexp:        baseExp,
-			Timebonusexp:   timeBonusExp,
-			Streakbonusexp: streakBonusExp,
-			Totalexp:       totalExp,
-		})
+		taskValue, levelUp, err = t.RecordCompletion(q, cardId, userId, baseExp, timeBonusExp, streakBonusExp)
 		return err
 	})
 
@@ -48,10 +88,104 @@ func (t *TaskCompletionService) CreateTaskCompletion(cardId int64, userId int64,
 		return database.TaskCompletion{}, err
 	}
 
+	t.PublishLevelUp(levelUp)
 	return taskValue, nil
 }
 
-// GetTaskCompletion retrieves a TaskCompletion record using cardId and userId
+// RecordCompletion creates a TaskCompletion and applies its EXP to the user's level, designed
+// to be used within a transaction. The returned event is non-nil when the user levelled up and
+// should be passed to PublishLevelUp once the transaction has committed.
+func (t *TaskCompletionService) RecordCompletion(q *database.Queries, cardId int64, userId int64, baseExp int64, timeBonusExp int64, streakBonusExp int64) (database.TaskCompletion, *events.LevelUpEvent, error) {
+	return t.recordCompletion(q, cardId, sql.NullInt64{}, userId, baseExp, timeBonusExp, streakBonusExp)
+}
+
+// RecordChecklistItemCompletion awards the partial EXP of a completed checklist item, designed
+// to be used within a transaction like RecordCompletion. Each item is awarded once.
+func (t *TaskCompletionService) RecordChecklistItemCompletion(q *database.Queries, cardId int64, checklistItemId int64, userId int64, exp int64) (database.TaskCompletion, *events.LevelUpEvent, error) {
+	return t.recordCompletion(q, cardId, sql.NullInt64{Int64: checklistItemId, Valid: true}, userId, exp, 0, 0)
+}
+
+func (t *TaskCompletionService) recordCompletion(q *database.Queries, cardId int64, checklistItemId sql.NullInt64, userId int64, baseExp int64, timeBonusExp int64, streakBonusExp int64) (database.TaskCompletion, *events.LevelUpEvent, error) {
+	totalExp := baseExp + timeBonusExp + streakBonusExp
+
+	taskValue, err := q.CreateTaskCompletion(t.ctx, database.CreateTaskCompletionParams{
+		Cardid:          cardId,
+		Userid:          userId,
+		Baseexp:         baseExp,
+		Timebonusexp:    timeBonusExp,
+		Streakbonusexp:  streakBonusExp,
+		Totalexp:        totalExp,
+		ChecklistItemId: checklistItemId,
+	})
+	if err != nil {
+		return database.TaskCompletion{}, nil, err
+	}
+
+	levelUp, err := applyExperience(t.ctx, q, loadLevelCurve(t.settingService), userId, totalExp)
+	if err != nil {
+		return database.TaskCompletion{}, nil, err
+	}
+
+	return taskValue, levelUp, nil
+}
+
+// PublishLevelUp publishes a level-up event returned by RecordCompletion. Nil events are ignored.
+func (t *TaskCompletionService) PublishLevelUp(event *events.LevelUpEvent) {
+	if event == nil {
+		return
+	}
+	t.eventBus.Publish(events.LevelUpTopic, *event)
+	log.Printf("Published LevelUpEvent: %+v", *event)
+}
+
+// WithdrawCardExperience takes the EXP the cards earned off the user's level when they are
+// trashed, designed to be used within the transaction that trashes them. The completions are
+// kept so ReturnCardExperience can give the EXP back. The returned event is nil when the
+// cards earned no EXP.
+func (t *TaskCompletionService) WithdrawCardExperience(q *database.Queries, cardIds []int64, userId int64) (*events.LevelChangedEvent, error) {
+	var exp int64
+	for _, cardId := range cardIds {
+		cardExp, err := q.SumCardExp(t.ctx, database.SumCardExpParams{Cardid: cardId, Userid: userId})
+		if err != nil {
+			return nil, fmt.Errorf("failed to sum card exp: %w", err)
+		}
+		exp += cardExp
+	}
+	return t.changeExperience(q, userId, -exp)
+}
+
+// ReturnCardExperience gives the EXP of a restored card back to the user's level, designed to
+// be used within the transaction that restores it.
+func (t *TaskCompletionService) ReturnCardExperience(q *database.Queries, cardId int64, userId int64) (*events.LevelChangedEvent, error) {
+	exp, err := q.SumCardExp(t.ctx, database.SumCardExpParams{Cardid: cardId, Userid: userId})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum card exp: %w", err)
+	}
+	return t.changeExperience(q, userId, exp)
+}
+
+func (t *TaskCompletionService) changeExperience(q *database.Queries, userId int64, exp int64) (*events.LevelChangedEvent, error) {
+	if exp == 0 {
+		return nil, nil
+	}
+	changed, err := changeExperience(t.ctx, q, loadLevelCurve(t.settingService), userId, exp)
+	if err != nil {
+		return nil, err
+	}
+	return &changed, nil
+}
+
+// PublishLevelChanged publishes an event returned by WithdrawCardExperience or
+// ReturnCardExperience. Nil events are ignored.
+func (t *TaskCompletionService) PublishLevelChanged(event *events.LevelChangedEvent) {
+	if event == nil {
+		return
+	}
+	t.eventBus.Publish(events.LevelChangedTopic, *event)
+	log.Printf("Published LevelChangedEvent: %+v", *event)
+}
+
+// GetTaskCompletion retrieves the completion of the card itself using cardId and userId
 func (t *TaskCompletionService) GetTaskCompletion(cardId int64, userId int64) (database.TaskCompletion, error) {
 	queries := t.dbManager.Queries(t.ctx)
 	return queries.GetTaskCompletion(t.ctx, database.GetTaskCompletionParams{
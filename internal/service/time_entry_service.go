@@ -0,0 +1,406 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/sriram15/progressor-todo-app/internal/connection"
+	"github.com/sriram15/progressor-todo-app/internal/database"
+	"github.com/sriram15/progressor-todo-app/internal/events"
+)
+
+var (
+	ErrInvalidTimeRange  = errors.New("time entry must end after it starts")
+	ErrTimeEntryInFuture = errors.New("time entry cannot end in the future")
+	ErrTimeEntryOverlap  = errors.New("time entry overlaps an existing time entry")
+	ErrTimeEntryActive   = errors.New("time entry is still being tracked")
+	ErrInvalidSplitTime  = errors.New("split time must fall inside the time entry")
+)
+
+// ITimeEntryService manages the time entries of a card outside of StartCard/StopCard, so that
+// forgotten timers and offline work can be corrected.
+type ITimeEntryService interface {
+	ListTimeEntries(projectId uint, cardId uint) ([]database.TimeEntry, error)
+	ListTimeEntriesInRange(from time.Time, to time.Time) ([]database.ListTimeEntriesInRangeRow, error)
+	AddTimeEntry(projectId uint, cardId uint, startTime time.Time, endTime time.Time) (*database.TimeEntry, error)
+	UpdateTimeEntry(projectId uint, cardId uint, entryId uint, startTime time.Time, endTime time.Time) error
+	DeleteTimeEntry(projectId uint, cardId uint, entryId uint) error
+	SplitTimeEntry(projectId uint, cardId uint, entryId uint, splitAt time.Time) error
+}
+
+type TimeEntryService struct {
+	ctx            context.Context
+	projectService IProjectService
+	dbManager      *connection.DBManager
+	eventBus       *events.EventBus
+}
+
+func NewTimeEntryService(projectService IProjectService, dbManager *connection.DBManager, eventBus *events.EventBus) *TimeEntryService {
+	return &TimeEntryService{
+		ctx:            context.Background(),
+		projectService: projectService,
+		dbManager:      dbManager,
+		eventBus:       eventBus,
+	}
+}
+
+// ListTimeEntries returns all time entries of a card in chronological order.
+func (t *TimeEntryService) ListTimeEntries(projectId uint, cardId uint) ([]database.TimeEntry, error) {
+	if _, err := t.projectService.IsValidProject(projectId); err != nil {
+		return nil, err
+	}
+
+	queries := t.dbManager.Queries(t.ctx)
+	if _, err := queries.GetCard(t.ctx, database.GetCardParams{ID: int64(cardId), Projectid: int64(projectId)}); err != nil {
+		return nil, ErrNotFound
+	}
+	return queries.ListTimeEntriesByCard(t.ctx, int64(cardId))
+}
+
+// ListTimeEntriesInRange returns the time entries of all cards that started within [from, to).
+func (t *TimeEntryService) ListTimeEntriesInRange(from time.Time, to time.Time) ([]database.ListTimeEntriesInRangeRow, error) {
+	if !to.After(from) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	queries := t.dbManager.Queries(t.ctx)
+	return queries.ListTimeEntriesInRange(t.ctx, database.ListTimeEntriesInRangeParams{
+		RangeStart: from.UTC(),
+		RangeEnd:   to.UTC(),
+	})
+}
+
+// AddTimeEntry records a past, already finished work session on a card.
+func (t *TimeEntryService) AddTimeEntry(projectId uint, cardId uint, startTime time.Time, endTime time.Time) (*database.TimeEntry, error) {
+	if _, err := t.projectService.IsValidProject(projectId); err != nil {
+		return nil, err
+	}
+
+	startTime, endTime = startTime.UTC(), endTime.UTC()
+	if err := validateTimeRange(startTime, endTime); err != nil {
+		return nil, err
+	}
+
+	var entry database.TimeEntry
+	var changedEvent events.TimeEntryChangedEvent
+	err := t.dbManager.Execute(t.ctx, func(q *database.Queries) error {
+		card, err := q.GetCard(t.ctx, database.GetCardParams{ID: int64(cardId), Projectid: int64(projectId)})
+		if err != nil {
+			return ErrNotFound
+		}
+
+		if err := t.checkOverlap(q, 0, startTime, endTime); err != nil {
+			return err
+		}
+
+		duration := trackedSeconds(startTime, endTime)
+		entry, err = q.InsertTimeEntry(t.ctx, database.InsertTimeEntryParams{
+			Cardid:    card.CardID,
+			Starttime: startTime,
+			Endtime:   endTime,
+			Duration:  duration,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := q.RecalculateCardTrackedTime(t.ctx, card.CardID); err != nil {
+			return err
+		}
+
+		changedEvent = newTimeEntryChangedEvent(card, duration)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t.publishChange(changedEvent)
+	return &entry, nil
+}
+
+// UpdateTimeEntry moves the start and end of a finished time entry.
+func (t *TimeEntryService) UpdateTimeEntry(projectId uint, cardId uint, entryId uint, startTime time.Time, endTime time.Time) error {
+	if _, err := t.projectService.IsValidProject(projectId); err != nil {
+		return err
+	}
+
+	startTime, endTime = startTime.UTC(), endTime.UTC()
+	if err := validateTimeRange(startTime, endTime); err != nil {
+		return err
+	}
+
+	var changedEvent events.TimeEntryChangedEvent
+	err := t.dbManager.Execute(t.ctx, func(q *database.Queries) error {
+		card, entry, err := t.getFinishedEntry(q, projectId, cardId, entryId)
+		if err != nil {
+			return err
+		}
+
+		if err := t.checkOverlap(q, entry.ID, startTime, endTime); err != nil {
+			return err
+		}
+
+		pauses, err := q.ListTimeEntryPauses(t.ctx, entry.ID)
+		if err != nil {
+			return err
+		}
+
+		duration := trackedSeconds(startTime, endTime) - pausedSeconds(pauses, startTime, endTime)
+		err = q.UpdateTimeEntry(t.ctx, database.UpdateTimeEntryParams{
+			ID:        entry.ID,
+			Starttime: startTime,
+			Endtime:   endTime,
+			Duration:  duration,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := q.RecalculateCardTrackedTime(t.ctx, card.CardID); err != nil {
+			return err
+		}
+
+		changedEvent = newTimeEntryChangedEvent(card, duration-entry.Duration)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	t.publishChange(changedEvent)
+	return nil
+}
+
+func (t *TimeEntryService) DeleteTimeEntry(projectId uint, cardId uint, entryId uint) error {
+	if _, err := t.projectService.IsValidProject(projectId); err != nil {
+		return err
+	}
+
+	var changedEvent events.TimeEntryChangedEvent
+	err := t.dbManager.Execute(t.ctx, func(q *database.Queries) error {
+		card, entry, err := t.getFinishedEntry(q, projectId, cardId, entryId)
+		if err != nil {
+			return err
+		}
+
+		if err := q.DeleteTimeEntry(t.ctx, entry.ID); err != nil {
+			return err
+		}
+
+		if err := q.RecalculateCardTrackedTime(t.ctx, card.CardID); err != nil {
+			return err
+		}
+
+		changedEvent = newTimeEntryChangedEvent(card, -entry.Duration)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	t.publishChange(changedEvent)
+	return nil
+}
+
+// SplitTimeEntry cuts a finished time entry in two at splitAt. The first part keeps the
+// original entry ID.
+func (t *TimeEntryService) SplitTimeEntry(projectId uint, cardId uint, entryId uint, splitAt time.Time) error {
+	if _, err := t.projectService.IsValidProject(projectId); err != nil {
+		return err
+	}
+
+	splitAt = splitAt.UTC()
+
+	var changedEvent events.TimeEntryChangedEvent
+	err := t.dbManager.Execute(t.ctx, func(q *database.Queries) error {
+		card, entry, err := t.getFinishedEntry(q, projectId, cardId, entryId)
+		if err != nil {
+			return err
+		}
+
+		if !splitAt.After(entry.Starttime) || !splitAt.Before(entry.Endtime) {
+			return ErrInvalidSplitTime
+		}
+
+		pauses, err := q.ListTimeEntryPauses(t.ctx, entry.ID)
+		if err != nil {
+			return err
+		}
+
+		firstDuration := trackedSeconds(entry.Starttime, splitAt) - pausedSeconds(pauses, entry.Starttime, splitAt)
+		err = q.UpdateTimeEntry(t.ctx, database.UpdateTimeEntryParams{
+			ID:        entry.ID,
+			Starttime: entry.Starttime,
+			Endtime:   splitAt,
+			Duration:  firstDuration,
+		})
+		if err != nil {
+			return err
+		}
+
+		secondDuration := trackedSeconds(splitAt, entry.Endtime) - pausedSeconds(pauses, splitAt, entry.Endtime)
+		second, err := q.InsertTimeEntry(t.ctx, database.InsertTimeEntryParams{
+			Cardid:    card.CardID,
+			Starttime: splitAt,
+			Endtime:   entry.Endtime,
+			Duration:  secondDuration,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := t.splitPauses(q, pauses, entry.ID, second.ID, splitAt); err != nil {
+			return err
+		}
+
+		if err := q.RecalculateCardTrackedTime(t.ctx, card.CardID); err != nil {
+			return err
+		}
+
+		// Whole-second truncation of the two halves can differ from the original duration.
+		changedEvent = newTimeEntryChangedEvent(card, firstDuration+secondDuration-entry.Duration)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	t.publishChange(changedEvent)
+	return nil
+}
+
+// splitPauses moves the pauses after splitAt to the second half of a split entry. A pause that
+// spans splitAt is cut in two, so that each half keeps its own part.
+func (t *TimeEntryService) splitPauses(q *database.Queries, pauses []database.TimeEntryPause, firstId int64, secondId int64, splitAt time.Time) error {
+	for _, pause := range pauses {
+		if !pause.Pausedat.Before(splitAt) || !pause.Resumedat.Valid || !pause.Resumedat.Time.After(splitAt) {
+			continue
+		}
+		err := q.ResumeTimeEntryPause(t.ctx, database.ResumeTimeEntryPauseParams{
+			ID:        pause.ID,
+			Resumedat: sql.NullTime{Time: splitAt, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		err = q.CreateTimeEntryPause(t.ctx, database.CreateTimeEntryPauseParams{
+			Timeentryid: secondId,
+			Pausedat:    splitAt,
+			Resumedat:   pause.Resumedat,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return q.MoveTimeEntryPauses(t.ctx, database.MoveTimeEntryPausesParams{
+		NewTimeEntryID: secondId,
+		TimeEntryID:    firstId,
+		PausedFrom:     splitAt,
+	})
+}
+
+// getFinishedEntry loads a card and one of its time entries, rejecting the entry that is
+// currently being tracked.
+func (t *TimeEntryService) getFinishedEntry(q *database.Queries, projectId uint, cardId uint, entryId uint) (database.GetCardRow, database.TimeEntry, error) {
+	card, err := q.GetCard(t.ctx, database.GetCardParams{ID: int64(cardId), Projectid: int64(projectId)})
+	if err != nil {
+		return database.GetCardRow{}, database.TimeEntry{}, ErrNotFound
+	}
+
+	entry, err := q.GetTimeEntry(t.ctx, database.GetTimeEntryParams{ID: int64(entryId), Cardid: card.CardID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return database.GetCardRow{}, database.TimeEntry{}, ErrNotFound
+		}
+		return database.GetCardRow{}, database.TimeEntry{}, err
+	}
+
+	if entry.Starttime.Equal(entry.Endtime) {
+		return database.GetCardRow{}, database.TimeEntry{}, ErrTimeEntryActive
+	}
+	return card, entry, nil
+}
+
+// checkOverlap rejects intervals that intersect any other time entry, across all cards,
+// since only one card can be tracked at a time. Running entries are treated as open-ended.
+func (t *TimeEntryService) checkOverlap(q *database.Queries, excludeId int64, startTime time.Time, endTime time.Time) error {
+	overlapping, err := q.ListOverlappingTimeEntries(t.ctx, database.ListOverlappingTimeEntriesParams{
+		ExcludeID:  excludeId,
+		RangeStart: startTime,
+		RangeEnd:   endTime,
+	})
+	if err != nil {
+		return err
+	}
+	if len(overlapping) > 0 {
+		return fmt.Errorf("%w (entry %d on card %d)", ErrTimeEntryOverlap, overlapping[0].ID, overlapping[0].Cardid)
+	}
+	return nil
+}
+
+func (t *TimeEntryService) publishChange(event events.TimeEntryChangedEvent) {
+	if event.Delta == 0 {
+		return
+	}
+	t.eventBus.Publish(events.TimeEntryChangedTopic, event)
+	log.Printf("Published TimeEntryChangedEvent: %+v", event)
+}
+
+func newTimeEntryChangedEvent(card database.GetCardRow, deltaSeconds int64) events.TimeEntryChangedEvent {
+	return events.TimeEntryChangedEvent{
+		CardID:    card.CardID,
+		ProjectID: card.Projectid,
+		UserID:    userId,
+		Delta:     time.Duration(deltaSeconds) * time.Second,
+		ChangedAt: time.Now().UTC(),
+	}
+}
+
+func validateTimeRange(startTime time.Time, endTime time.Time) error {
+	if !endTime.After(startTime) {
+		return ErrInvalidTimeRange
+	}
+	if endTime.After(time.Now().UTC()) {
+		return ErrTimeEntryInFuture
+	}
+	return nil
+}
+
+// trackedSeconds is the duration stored for a time entry, truncated to whole seconds the
+// same way stopCardLogic does.
+func trackedSeconds(startTime time.Time, endTime time.Time) int64 {
+	return int64(endTime.Sub(startTime) / time.Second)
+}
+
+// pausedSeconds is the part of [startTime, endTime] covered by pauses, in whole seconds. A pause
+// that is still open lasts until endTime, and time covered by overlapping pauses counts once.
+func pausedSeconds(pauses []database.TimeEntryPause, startTime time.Time, endTime time.Time) int64 {
+	pauses = slices.Clone(pauses)
+	slices.SortFunc(pauses, func(a, b database.TimeEntryPause) int {
+		return a.Pausedat.Compare(b.Pausedat)
+	})
+
+	var paused time.Duration
+	covered := startTime
+	for _, pause := range pauses {
+		from, to := pause.Pausedat, endTime
+		if pause.Resumedat.Valid && pause.Resumedat.Time.Before(to) {
+			to = pause.Resumedat.Time
+		}
+		if from.Before(covered) {
+			from = covered
+		}
+		if to.After(from) {
+			paused += to.Sub(from)
+			covered = to
+		}
+	}
+	return int64(paused / time.Second)
+}
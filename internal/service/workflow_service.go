@@ -0,0 +1,418 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/sriram15/progressor-todo-app/internal/connection"
+	"github.com/sriram15/progressor-todo-app/internal/database"
+)
+
+var (
+	ErrWorkflowStatusNameRequired = errors.New("workflow status name is required")
+	ErrWorkflowStatusNameTaken    = errors.New("the project already has a status with this name")
+	ErrInvalidWorkflowStatus      = errors.New("invalid workflow status")
+	ErrInvalidWorkflowOrder       = errors.New("workflow order must list every status of the project once")
+	ErrWorkflowNeedsOpenAndDone   = errors.New("a workflow needs at least one open and one done status")
+	ErrWorkflowStatusInUse        = errors.New("move the cards out of this status before changing whether it counts as done")
+	ErrInvalidWorkflowReassign    = errors.New("cards must be moved to another status of the project that counts as done the same way")
+	ErrInvalidWorkflowTransition  = errors.New("a transition must join two different statuses of the project")
+)
+
+// defaultWorkflow lists the statuses every new project starts with, in board order. Every
+// transition between them is allowed.
+var defaultWorkflow = []struct {
+	name   string
+	isDone bool
+}{
+	{"Backlog", false},
+	{"Doing", false},
+	{"Review", false},
+	{"Done", true},
+}
+
+// Workflow is a project's statuses in board order and the transitions allowed between them.
+type Workflow struct {
+	Statuses    []database.WorkflowStatus     `json:"statuses"`
+	Transitions []database.WorkflowTransition `json:"transitions"`
+}
+
+// IWorkflowService manages the statuses a project's cards move through and the transitions
+// allowed between them. Cards in a status that counts as done are done and earn EXP.
+type IWorkflowService interface {
+	GetWorkflow(projectId uint) (*Workflow, error)
+	AddWorkflowStatus(projectId uint, name string, isDone bool) (*database.WorkflowStatus, error)
+	UpdateWorkflowStatus(projectId uint, statusId int64, name string, isDone bool) error
+	ReorderWorkflowStatuses(projectId uint, statusIds []int64) error
+	DeleteWorkflowStatus(projectId uint, statusId int64, moveToStatusId int64) error
+	SetWorkflowTransitions(projectId uint, transitions []database.WorkflowTransition) error
+}
+
+type WorkflowService struct {
+	ctx            context.Context
+	projectService IProjectService
+	dbManager      *connection.DBManager
+}
+
+func NewWorkflowService(projectService IProjectService, dbManager *connection.DBManager) *WorkflowService {
+	return &WorkflowService{
+		ctx:            context.Background(),
+		projectService: projectService,
+		dbManager:      dbManager,
+	}
+}
+
+func (w *WorkflowService) GetWorkflow(projectId uint) (*Workflow, error) {
+	if _, err := w.projectService.IsValidProject(projectId); err != nil {
+		return nil, err
+	}
+
+	queries := w.dbManager.Queries(w.ctx)
+	statuses, err := queries.ListWorkflowStatuses(w.ctx, int64(projectId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow statuses: %w", err)
+	}
+	transitions, err := queries.ListWorkflowTransitions(w.ctx, int64(projectId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow transitions: %w", err)
+	}
+	return &Workflow{Statuses: statuses, Transitions: transitions}, nil
+}
+
+// AddWorkflowStatus adds a status at the end of a project's workflow. Transitions to and from
+// every other status are allowed until SetWorkflowTransitions says otherwise.
+func (w *WorkflowService) AddWorkflowStatus(projectId uint, name string, isDone bool) (*database.WorkflowStatus, error) {
+	if _, err := w.projectService.IsValidProject(projectId); err != nil {
+		return nil, err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrWorkflowStatusNameRequired
+	}
+
+	var status database.WorkflowStatus
+	err := w.dbManager.Execute(w.ctx, func(q *database.Queries) error {
+		if err := checkWorkflowStatusName(w.ctx, q, int64(projectId), 0, name); err != nil {
+			return err
+		}
+
+		statuses, err := q.ListWorkflowStatuses(w.ctx, int64(projectId))
+		if err != nil {
+			return err
+		}
+		maxPosition, err := q.GetMaxWorkflowStatusPosition(w.ctx, int64(projectId))
+		if err != nil {
+			return err
+		}
+
+		status, err = q.CreateWorkflowStatus(w.ctx, database.CreateWorkflowStatusParams{
+			Projectid: int64(projectId),
+			Name:      name,
+			Position:  maxPosition + 1,
+			Isdone:    isDone,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, other := range statuses {
+			if err := addWorkflowTransitions(w.ctx, q, status.ID, other.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error adding workflow status: %v", err)
+		return nil, fmt.Errorf("failed to add workflow status: %w", err)
+	}
+	return &status, nil
+}
+
+// UpdateWorkflowStatus renames a status and sets whether it counts as done. Whether it counts as
+// done can only change while no cards are in it, so cards never complete or reopen as a side
+// effect.
+func (w *WorkflowService) UpdateWorkflowStatus(projectId uint, statusId int64, name string, isDone bool) error {
+	if _, err := w.projectService.IsValidProject(projectId); err != nil {
+		return err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrWorkflowStatusNameRequired
+	}
+
+	return w.dbManager.Execute(w.ctx, func(q *database.Queries) error {
+		status, err := getWorkflowStatus(w.ctx, q, int64(projectId), statusId)
+		if err != nil {
+			return err
+		}
+		if err := checkWorkflowStatusName(w.ctx, q, int64(projectId), statusId, name); err != nil {
+			return err
+		}
+
+		if status.Isdone != isDone {
+			cards, err := q.CountCardsInWorkflowStatus(w.ctx, sql.NullInt64{Valid: true, Int64: statusId})
+			if err != nil {
+				return err
+			}
+			if cards > 0 {
+				return ErrWorkflowStatusInUse
+			}
+
+			statuses, err := q.ListWorkflowStatuses(w.ctx, int64(projectId))
+			if err != nil {
+				return err
+			}
+			for i := range statuses {
+				if statuses[i].ID == statusId {
+					statuses[i].Isdone = isDone
+				}
+			}
+			if !workflowHasOpenAndDone(statuses) {
+				return ErrWorkflowNeedsOpenAndDone
+			}
+		}
+
+		return q.UpdateWorkflowStatus(w.ctx, database.UpdateWorkflowStatusParams{
+			Name:   name,
+			Isdone: isDone,
+			ID:     statusId,
+		})
+	})
+}
+
+// ReorderWorkflowStatuses sets the board order of a project's statuses. statusIds must list
+// every status of the project exactly once.
+func (w *WorkflowService) ReorderWorkflowStatuses(projectId uint, statusIds []int64) error {
+	if _, err := w.projectService.IsValidProject(projectId); err != nil {
+		return err
+	}
+
+	return w.dbManager.Execute(w.ctx, func(q *database.Queries) error {
+		statuses, err := q.ListWorkflowStatuses(w.ctx, int64(projectId))
+		if err != nil {
+			return err
+		}
+		if len(statusIds) != len(statuses) {
+			return ErrInvalidWorkflowOrder
+		}
+
+		remaining := make(map[int64]bool, len(statuses))
+		for _, status := range statuses {
+			remaining[status.ID] = true
+		}
+		for _, id := range statusIds {
+			if !remaining[id] {
+				return ErrInvalidWorkflowOrder
+			}
+			delete(remaining, id)
+		}
+
+		for position, id := range statusIds {
+			err := q.UpdateWorkflowStatusPosition(w.ctx, database.UpdateWorkflowStatusPositionParams{
+				Position: int64(position),
+				ID:       id,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteWorkflowStatus removes a status after moving its cards, including trashed ones, to
+// moveToStatusId. The two statuses must both count as done or both not, so no card completes or
+// reopens.
+func (w *WorkflowService) DeleteWorkflowStatus(projectId uint, statusId int64, moveToStatusId int64) error {
+	if _, err := w.projectService.IsValidProject(projectId); err != nil {
+		return err
+	}
+
+	return w.dbManager.Execute(w.ctx, func(q *database.Queries) error {
+		status, err := getWorkflowStatus(w.ctx, q, int64(projectId), statusId)
+		if err != nil {
+			return err
+		}
+		moveTo, err := getWorkflowStatus(w.ctx, q, int64(projectId), moveToStatusId)
+		if err != nil {
+			if errors.Is(err, ErrInvalidWorkflowStatus) {
+				return ErrInvalidWorkflowReassign
+			}
+			return err
+		}
+		if err := checkWorkflowReassign(status, moveTo); err != nil {
+			return err
+		}
+
+		err = q.MoveCardsToWorkflowStatus(w.ctx, database.MoveCardsToWorkflowStatusParams{
+			TargetStatusID: sql.NullInt64{Valid: true, Int64: moveTo.ID},
+			SourceStatusID: sql.NullInt64{Valid: true, Int64: status.ID},
+		})
+		if err != nil {
+			return err
+		}
+		if err := q.DeleteWorkflowTransitionsForStatus(w.ctx, status.ID); err != nil {
+			return err
+		}
+		return q.DeleteWorkflowStatus(w.ctx, status.ID)
+	})
+}
+
+// SetWorkflowTransitions replaces the transitions allowed between a project's statuses. A card
+// can only move from one status to another along one of them.
+func (w *WorkflowService) SetWorkflowTransitions(projectId uint, transitions []database.WorkflowTransition) error {
+	if _, err := w.projectService.IsValidProject(projectId); err != nil {
+		return err
+	}
+
+	return w.dbManager.Execute(w.ctx, func(q *database.Queries) error {
+		statuses, err := q.ListWorkflowStatuses(w.ctx, int64(projectId))
+		if err != nil {
+			return err
+		}
+		inProject := make(map[int64]bool, len(statuses))
+		for _, status := range statuses {
+			inProject[status.ID] = true
+		}
+		for _, transition := range transitions {
+			if transition.Fromstatusid == transition.Tostatusid || !inProject[transition.Fromstatusid] || !inProject[transition.Tostatusid] {
+				return ErrInvalidWorkflowTransition
+			}
+		}
+
+		if err := q.DeleteWorkflowTransitionsForProject(w.ctx, int64(projectId)); err != nil {
+			return err
+		}
+		for _, transition := range transitions {
+			err := q.AddWorkflowTransition(w.ctx, database.AddWorkflowTransitionParams{
+				Fromstatusid: transition.Fromstatusid,
+				Tostatusid:   transition.Tostatusid,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// createDefaultWorkflow gives a new project the default statuses, with every transition between
+// them allowed.
+func createDefaultWorkflow(ctx context.Context, q *database.Queries, projectID int64) error {
+	var created []database.WorkflowStatus
+	for position, def := range defaultWorkflow {
+		status, err := q.CreateWorkflowStatus(ctx, database.CreateWorkflowStatusParams{
+			Projectid: projectID,
+			Name:      def.name,
+			Position:  int64(position),
+			Isdone:    def.isDone,
+		})
+		if err != nil {
+			return err
+		}
+		for _, other := range created {
+			if err := addWorkflowTransitions(ctx, q, status.ID, other.ID); err != nil {
+				return err
+			}
+		}
+		created = append(created, status)
+	}
+	return nil
+}
+
+// addWorkflowTransitions allows moving cards between two statuses in both directions.
+func addWorkflowTransitions(ctx context.Context, q *database.Queries, a int64, b int64) error {
+	err := q.AddWorkflowTransition(ctx, database.AddWorkflowTransitionParams{Fromstatusid: a, Tostatusid: b})
+	if err != nil {
+		return err
+	}
+	return q.AddWorkflowTransition(ctx, database.AddWorkflowTransitionParams{Fromstatusid: b, Tostatusid: a})
+}
+
+// getWorkflowStatus loads a status of a project, mapping a missing row or a status of another
+// project to ErrInvalidWorkflowStatus.
+func getWorkflowStatus(ctx context.Context, q *database.Queries, projectID int64, statusID int64) (database.WorkflowStatus, error) {
+	status, err := q.GetWorkflowStatus(ctx, database.GetWorkflowStatusParams{ID: statusID, Projectid: projectID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return database.WorkflowStatus{}, ErrInvalidWorkflowStatus
+		}
+		return database.WorkflowStatus{}, err
+	}
+	return status, nil
+}
+
+// checkWorkflowStatusName rejects a name already used by another status of the project than
+// excludeId.
+func checkWorkflowStatusName(ctx context.Context, q *database.Queries, projectID int64, excludeId int64, name string) error {
+	existing, err := q.GetWorkflowStatusByName(ctx, database.GetWorkflowStatusByNameParams{Projectid: projectID, Name: name})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.ID != excludeId {
+		return ErrWorkflowStatusNameTaken
+	}
+	return nil
+}
+
+// workflowHasOpenAndDone reports whether cards can be both open and done in a workflow.
+func workflowHasOpenAndDone(statuses []database.WorkflowStatus) bool {
+	var open, done bool
+	for _, status := range statuses {
+		if status.Isdone {
+			done = true
+		} else {
+			open = true
+		}
+	}
+	return open && done
+}
+
+// pickWorkflowStatus returns the status, of statuses in board order, that a card in currentID is
+// placed in when it is marked done or not done: its current status if that is already of the
+// right kind, otherwise the first status that is. ok is false when no status is of that kind.
+func pickWorkflowStatus(statuses []database.WorkflowStatus, currentID sql.NullInt64, status CardStatus) (database.WorkflowStatus, bool) {
+	isDone := status == Done
+	if currentID.Valid {
+		i := slices.IndexFunc(statuses, func(s database.WorkflowStatus) bool { return s.ID == currentID.Int64 })
+		if i >= 0 && statuses[i].Isdone == isDone {
+			return statuses[i], true
+		}
+	}
+	i := slices.IndexFunc(statuses, func(s database.WorkflowStatus) bool { return s.Isdone == isDone })
+	if i < 0 {
+		return database.WorkflowStatus{}, false
+	}
+	return statuses[i], true
+}
+
+// checkWorkflowTransition returns ErrTransitionNotAllowed unless transitions allow moving a card
+// from fromID to toID. Staying in the same status, or a card without a status (fromID 0), is
+// always allowed.
+func checkWorkflowTransition(fromID int64, toID int64, transitions []database.WorkflowTransition) error {
+	if fromID == 0 || fromID == toID {
+		return nil
+	}
+	if slices.Contains(transitions, database.WorkflowTransition{Fromstatusid: fromID, Tostatusid: toID}) {
+		return nil
+	}
+	return ErrTransitionNotAllowed
+}
+
+// checkWorkflowReassign returns ErrInvalidWorkflowReassign unless the cards of a deleted status
+// can move to moveTo: another status that counts as done the same way.
+func checkWorkflowReassign(status database.WorkflowStatus, moveTo database.WorkflowStatus) error {
+	if status.ID == moveTo.ID || status.Isdone != moveTo.Isdone {
+		return ErrInvalidWorkflowReassign
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/sriram15/progressor-todo-app/internal/database"
+)
+
+func TestWorkflowHasOpenAndDone(t *testing.T) {
+	open := database.WorkflowStatus{Name: "Backlog"}
+	done := database.WorkflowStatus{Name: "Done", Isdone: true}
+
+	tests := []struct {
+		name     string
+		statuses []database.WorkflowStatus
+		want     bool
+	}{
+		{name: "open and done", statuses: []database.WorkflowStatus{open, done}, want: true},
+		{name: "only open", statuses: []database.WorkflowStatus{open, open}},
+		{name: "only done", statuses: []database.WorkflowStatus{done}},
+		{name: "empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := workflowHasOpenAndDone(tt.statuses); got != tt.want {
+				t.Errorf("workflowHasOpenAndDone() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultWorkflowHasOpenAndDone(t *testing.T) {
+	statuses := make([]database.WorkflowStatus, len(defaultWorkflow))
+	for i, def := range defaultWorkflow {
+		statuses[i] = database.WorkflowStatus{Name: def.name, Isdone: def.isDone}
+	}
+	if !workflowHasOpenAndDone(statuses) {
+		t.Error("default workflow needs an open and a done status")
+	}
+}
+
+func TestPickWorkflowStatus(t *testing.T) {
+	statuses := []database.WorkflowStatus{
+		{ID: 1, Name: "Backlog"},
+		{ID: 2, Name: "Doing"},
+		{ID: 3, Name: "Done", Isdone: true},
+		{ID: 4, Name: "Archived", Isdone: true},
+	}
+
+	tests := []struct {
+		name      string
+		statuses  []database.WorkflowStatus
+		currentID sql.NullInt64
+		status    CardStatus
+		wantID    int64
+		wantOk    bool
+	}{
+		{name: "open card stays in its open status", statuses: statuses, currentID: sql.NullInt64{Valid: true, Int64: 2}, status: Todo, wantID: 2, wantOk: true},
+		{name: "done card stays in its done status", statuses: statuses, currentID: sql.NullInt64{Valid: true, Int64: 4}, status: Done, wantID: 4, wantOk: true},
+		{name: "completing moves to the first done status", statuses: statuses, currentID: sql.NullInt64{Valid: true, Int64: 2}, status: Done, wantID: 3, wantOk: true},
+		{name: "reopening moves to the first open status", statuses: statuses, currentID: sql.NullInt64{Valid: true, Int64: 4}, status: Todo, wantID: 1, wantOk: true},
+		{name: "card without a status", statuses: statuses, status: Done, wantID: 3, wantOk: true},
+		{name: "status of another project", statuses: statuses, currentID: sql.NullInt64{Valid: true, Int64: 9}, status: Todo, wantID: 1, wantOk: true},
+		{name: "no status of the right kind", statuses: statuses[:2], status: Done},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := pickWorkflowStatus(tt.statuses, tt.currentID, tt.status)
+			if ok != tt.wantOk || got.ID != tt.wantID {
+				t.Errorf("pickWorkflowStatus() = %d, %v, want %d, %v", got.ID, ok, tt.wantID, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestCheckWorkflowTransition(t *testing.T) {
+	transitions := []database.WorkflowTransition{
+		{Fromstatusid: 1, Tostatusid: 2},
+		{Fromstatusid: 2, Tostatusid: 3},
+	}
+
+	tests := []struct {
+		name    string
+		fromID  int64
+		toID    int64
+		wantErr error
+	}{
+		{name: "allowed transition", fromID: 1, toID: 2},
+		{name: "same status", fromID: 3, toID: 3},
+		{name: "card without a status", fromID: 0, toID: 3},
+		{name: "skipping a status", fromID: 1, toID: 3, wantErr: ErrTransitionNotAllowed},
+		{name: "transitions are one way", fromID: 2, toID: 1, wantErr: ErrTransitionNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkWorkflowTransition(tt.fromID, tt.toID, transitions)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkWorkflowTransition() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckWorkflowReassign(t *testing.T) {
+	backlog := database.WorkflowStatus{ID: 1, Name: "Backlog"}
+	doing := database.WorkflowStatus{ID: 2, Name: "Doing"}
+	done := database.WorkflowStatus{ID: 3, Name: "Done", Isdone: true}
+
+	tests := []struct {
+		name    string
+		status  database.WorkflowStatus
+		moveTo  database.WorkflowStatus
+		wantErr error
+	}{
+		{name: "open to open", status: doing, moveTo: backlog},
+		{name: "open to done", status: doing, moveTo: done, wantErr: ErrInvalidWorkflowReassign},
+		{name: "done to open", status: done, moveTo: backlog, wantErr: ErrInvalidWorkflowReassign},
+		{name: "to itself", status: doing, moveTo: doing, wantErr: ErrInvalidWorkflowReassign},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkWorkflowReassign(tt.status, tt.moveTo)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkWorkflowReassign() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
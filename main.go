@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/sriram15/progressor-todo-app/internal/events"
 	"github.com/wailsapp/wails/v3/pkg/application"
 	"github.com/wailsapp/wails/v3/pkg/icons"
 	"github.com/wailsapp/wails/v3/pkg/services/notifications"
@@ -80,6 +81,17 @@ func main() {
 	})
     systemTray.SetMenu(myMenu)
 
+	// Show the focus timer's phase in the tray
+	wailsApp.Event.On(events.FocusPhaseChangedTopic, func(event *application.CustomEvent) {
+		data, ok := event.Data.([]any)
+		if !ok || len(data) == 0 {
+			return
+		}
+		if phase, ok := data[0].(events.FocusPhaseChangedEvent); ok {
+			systemTray.SetTooltip(focusPhaseTooltip(phase))
+		}
+	})
+
 	go func() {
 		for {
 			now := time.Now().Format(time.RFC1123)
@@ -91,4 +103,22 @@ func main() {
 	if err := wailsApp.Run(); err != nil {
 		log.Fatal(err)
 	}
-}
\ No newline at end of file
+}
+
+// focusPhaseTooltip describes the focus timer's phase for the system tray.
+func focusPhaseTooltip(event events.FocusPhaseChangedEvent) string {
+	endsAt := event.EndsAt.Local().Format("15:04")
+	switch event.Phase {
+	case events.FocusPhaseWork:
+		if event.Paused {
+			return "Progressor - Focus paused"
+		}
+		return "Progressor - Focus until " + endsAt
+	case events.FocusPhaseShortBreak:
+		return "Progressor - Break until " + endsAt
+	case events.FocusPhaseLongBreak:
+		return "Progressor - Long break until " + endsAt
+	default:
+		return "Progressor"
+	}
+}